@@ -0,0 +1,279 @@
+package butlerd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"crawshaw.io/sqlite"
+	"github.com/google/uuid"
+	"github.com/helloeave/json"
+	"github.com/itchio/butler/butlerd/horror"
+	"github.com/itchio/butler/database/models"
+	"github.com/pkg/errors"
+)
+
+// After that many failed attempts, a background task is given up on
+const backgroundTaskMaxAttempts = 8
+
+// Delay before the first retry, doubled after each failed attempt.
+// These are variables so tests don't have to wait that long.
+var (
+	backgroundTaskBaseDelay = 10 * time.Second
+	backgroundTaskMaxDelay  = 1 * time.Hour
+)
+
+// RegisterBackgroundTask sets the handler for background tasks of a given kind.
+// The task's parameters are passed as the RequestContext's Params.
+//
+// Like Register, it must be called before the router starts serving, and
+// before ResumeBackgroundTasks is called.
+func (r *Router) RegisterBackgroundTask(kind string, f BackgroundTaskFunc) {
+	if _, ok := r.backgroundTaskHandlers[kind]; ok {
+		panic(fmt.Sprintf("Can't register background task handler twice for %s", kind))
+	}
+	r.backgroundTaskHandlers[kind] = f
+}
+
+// QueueBackgroundTask persists a background task and starts it right away,
+// unless an identical task is already pending.
+func (r *Router) QueueBackgroundTask(bt BackgroundTask) {
+	err := func() error {
+		params, err := json.Marshal(bt.Params)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		now := time.Now().UTC()
+		task := &models.BackgroundTask{
+			ID:            uuid.New().String(),
+			Kind:          bt.Kind,
+			Params:        string(params),
+			Desc:          bt.Desc,
+			QueuedAt:      &now,
+			NextAttemptAt: &now,
+		}
+
+		alreadyPending := false
+		err = r.withConn(func(conn *sqlite.Conn) {
+			if models.PendingBackgroundTaskLike(conn, task.Kind, task.Params) != nil {
+				alreadyPending = true
+				return
+			}
+			task.Save(conn)
+		})
+		if err != nil {
+			return err
+		}
+
+		if alreadyPending {
+			r.globalConsumer.Debugf("Not queuing background task (%s): already pending", bt.Desc)
+			return nil
+		}
+
+		r.startBackgroundTask(task)
+		return nil
+	}()
+	if err != nil {
+		r.globalConsumer.Warnf("Could not queue background task (%s): %+v", bt.Desc, err)
+	}
+}
+
+// ResumeBackgroundTasks starts all background tasks left over from a previous
+// run, except those that were given up on.
+func (r *Router) ResumeBackgroundTasks() error {
+	var tasks []*models.BackgroundTask
+	err := r.withConn(func(conn *sqlite.Conn) {
+		tasks = models.PendingBackgroundTasks(conn)
+	})
+	if err != nil {
+		return errors.WithMessage(err, "listing pending background tasks")
+	}
+
+	if len(tasks) > 0 {
+		r.Logf("Resuming %d background tasks", len(tasks))
+	}
+	for _, task := range tasks {
+		r.startBackgroundTask(task)
+	}
+	return nil
+}
+
+// CancelBackgroundTask stops a background task if it's running, and
+// removes it from the database. Returns false if no such task exists.
+func (r *Router) CancelBackgroundTask(id BackgroundTaskID) bool {
+	r.backgroundTasksLock.Lock()
+	defer r.backgroundTasksLock.Unlock()
+
+	r.inflightLock.Lock()
+	if task, ok := r.inflightBackgroundTasks[id]; ok {
+		task.cancel()
+	}
+	r.inflightLock.Unlock()
+
+	var found bool
+	err := r.withConn(func(conn *sqlite.Conn) {
+		if models.BackgroundTaskByID(conn, string(id)) != nil {
+			found = true
+			models.DeleteBackgroundTask(conn, string(id))
+		}
+	})
+	if err != nil {
+		r.globalConsumer.Warnf("Could not delete background task %s: %+v", id, err)
+	}
+	return found
+}
+
+func (r *Router) startBackgroundTask(task *models.BackgroundTask) {
+	ctx, cancel := context.WithCancel(r.backgroundContext)
+
+	id := BackgroundTaskID(task.ID)
+	r.inflightLock.Lock()
+	r.onBackgroundTaskQueued(id, InFlightBackgroundTask{
+		QueuedAt: time.Now().UTC(),
+		Desc:     fmt.Sprintf("[task %s] %s", id, task.Desc),
+		cancel:   cancel,
+	})
+	r.inflightLock.Unlock()
+
+	go r.doBackgroundTask(ctx, task)
+}
+
+func (r *Router) doBackgroundTask(ctx context.Context, task *models.BackgroundTask) {
+	id := BackgroundTaskID(task.ID)
+	defer func() {
+		r.inflightLock.Lock()
+		r.inflightBackgroundTasks[id].cancel()
+		r.onBackgroundTaskFinished(id)
+		r.inflightLock.Unlock()
+	}()
+
+	consumer := r.globalConsumer
+	for {
+		if task.NextAttemptAt != nil {
+			if delay := time.Until(*task.NextAttemptAt); delay > 0 {
+				consumer.Debugf("Background task %s: next attempt in %v", id, delay)
+				select {
+				case <-time.After(delay):
+					// continue
+				case <-ctx.Done():
+					// cancelled or shutting down, it'll be resumed on next start
+					return
+				}
+			}
+		}
+
+		consumer.Debugf("Executing background task %s: %s", id, task.Desc)
+		err := r.runBackgroundTask(ctx, task)
+		if err == nil {
+			err := r.withConn(func(conn *sqlite.Conn) {
+				models.DeleteBackgroundTask(conn, task.ID)
+			})
+			if err != nil {
+				consumer.Warnf("Could not delete completed background task %s: %+v", id, err)
+			}
+			return
+		}
+
+		consumer.Warnf("Background task error: %+v", err)
+
+		gaveUp := r.scheduleRetry(ctx, task, err)
+		if gaveUp || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (r *Router) runBackgroundTask(ctx context.Context, task *models.BackgroundTask) (retErr error) {
+	defer horror.RecoverInto(&retErr)
+
+	f, ok := r.backgroundTaskHandlers[task.Kind]
+	if !ok {
+		return errors.Errorf("No handler for background task kind (%s)", task.Kind)
+	}
+
+	params := json.RawMessage(task.Params)
+	rc := &RequestContext{
+		Ctx:         ctx,
+		Consumer:    r.globalConsumer,
+		Params:      &params,
+		Conn:        nil,
+		CancelFuncs: r.CancelFuncs,
+		dbPool:      r.dbPool,
+		Client:      r.getClient,
+
+		HTTPClient:    r.httpClient,
+		HTTPTransport: r.httpTransport,
+
+		Group:    r.Group,
+		Shutdown: r.initiateShutdown,
+
+		method: "",
+
+		QueueBackgroundTask:  r.QueueBackgroundTask,
+		CancelBackgroundTask: r.CancelBackgroundTask,
+	}
+	return f(rc)
+}
+
+// scheduleRetry records a failed attempt, and returns true if the
+// task was given up on.
+func (r *Router) scheduleRetry(ctx context.Context, task *models.BackgroundTask, taskErr error) bool {
+	r.backgroundTasksLock.Lock()
+	defer r.backgroundTasksLock.Unlock()
+
+	if ctx.Err() != nil {
+		// cancelled tasks must not be saved again, and when shutting down,
+		// failures are probably due to the shutdown itself.
+		return false
+	}
+
+	now := time.Now().UTC()
+	lastError := fmt.Sprintf("%v", taskErr)
+	task.Attempts++
+	task.LastError = &lastError
+
+	gaveUp := false
+	if task.Attempts >= backgroundTaskMaxAttempts {
+		r.globalConsumer.Warnf("Giving up on background task %s after %d attempts", task.ID, task.Attempts)
+		task.GaveUpAt = &now
+		gaveUp = true
+	} else {
+		nextAttemptAt := now.Add(backgroundTaskDelay(task.Attempts))
+		task.NextAttemptAt = &nextAttemptAt
+	}
+
+	err := r.withConn(func(conn *sqlite.Conn) {
+		task.Save(conn)
+	})
+	if err != nil {
+		r.globalConsumer.Warnf("Could not save background task %s: %+v", task.ID, err)
+	}
+	return gaveUp
+}
+
+func backgroundTaskDelay(attempts int64) time.Duration {
+	delay := backgroundTaskBaseDelay
+	for i := int64(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= backgroundTaskMaxDelay {
+			return backgroundTaskMaxDelay
+		}
+	}
+	return delay
+}
+
+func (r *Router) withConn(f func(conn *sqlite.Conn)) (retErr error) {
+	defer horror.RecoverInto(&retErr)
+
+	getCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	conn := r.dbPool.Get(getCtx)
+	if conn == nil {
+		return errors.WithStack(CodeDatabaseBusy)
+	}
+	defer r.dbPool.Put(conn)
+
+	f(conn)
+	return nil
+}
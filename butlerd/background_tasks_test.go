@@ -0,0 +1,216 @@
+package butlerd
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"crawshaw.io/sqlite"
+	"crawshaw.io/sqlite/sqlitex"
+	"github.com/itchio/butler/database"
+	"github.com/itchio/butler/database/models"
+	"github.com/itchio/headway/state"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRouter(t *testing.T, dbPool *sqlitex.Pool) *Router {
+	r := NewRouter(dbPool, nil, nil, nil)
+	r.globalConsumer = &state.Consumer{
+		OnMessage: func(lvl string, msg string) {
+			t.Logf("[%s] %s", lvl, msg)
+		},
+	}
+	return r
+}
+
+func openTestDB(t *testing.T, name string) *sqlitex.Pool {
+	dbPool, err := sqlitex.Open("file:"+name+"?mode=memory&cache=shared", 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn := dbPool.Get(context.Background())
+	defer dbPool.Put(conn)
+	err = database.Prepare(&state.Consumer{}, conn, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dbPool
+}
+
+func withTestConn(dbPool *sqlitex.Pool, f func(conn *sqlite.Conn)) {
+	conn := dbPool.Get(context.Background())
+	defer dbPool.Put(conn)
+	f(conn)
+}
+
+func shortenBackgroundTaskDelays(t *testing.T) {
+	oldBase, oldMax := backgroundTaskBaseDelay, backgroundTaskMaxDelay
+	backgroundTaskBaseDelay = 20 * time.Millisecond
+	backgroundTaskMaxDelay = 80 * time.Millisecond
+	t.Cleanup(func() {
+		backgroundTaskBaseDelay, backgroundTaskMaxDelay = oldBase, oldMax
+	})
+}
+
+func Test_BackgroundTaskRetry(t *testing.T) {
+	assert := assert.New(t)
+	shortenBackgroundTaskDelays(t)
+
+	dbPool := openTestDB(t, "bgtasks-retry")
+	defer dbPool.Close()
+	r := newTestRouter(t, dbPool)
+	defer r.backgroundCancel()
+
+	var lock sync.Mutex
+	var attempts []time.Time
+	done := make(chan struct{})
+	r.RegisterBackgroundTask("flaky", func(rc *RequestContext) error {
+		lock.Lock()
+		defer lock.Unlock()
+
+		attempts = append(attempts, time.Now())
+		if len(attempts) < 3 {
+			return errors.Errorf("attempt %d failed", len(attempts))
+		}
+		close(done)
+		return nil
+	})
+
+	r.QueueBackgroundTask(BackgroundTask{
+		Kind:   "flaky",
+		Params: map[string]interface{}{"gameId": 123},
+		Desc:   "flaky task",
+	})
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for background task to succeed")
+	}
+
+	lock.Lock()
+	assert.Len(attempts, 3)
+	// the delay doubles after each failed attempt
+	assert.True(attempts[1].Sub(attempts[0]) >= backgroundTaskBaseDelay)
+	assert.True(attempts[2].Sub(attempts[1]) >= 2*backgroundTaskBaseDelay)
+	lock.Unlock()
+
+	// once it succeeds, it's removed from the database
+	assert.Eventually(func() bool {
+		var tasks []*models.BackgroundTask
+		withTestConn(dbPool, func(conn *sqlite.Conn) {
+			tasks = models.AllBackgroundTasks(conn)
+		})
+		return len(tasks) == 0
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func Test_BackgroundTaskGiveUp(t *testing.T) {
+	assert := assert.New(t)
+	shortenBackgroundTaskDelays(t)
+
+	dbPool := openTestDB(t, "bgtasks-giveup")
+	defer dbPool.Close()
+	r := newTestRouter(t, dbPool)
+	defer r.backgroundCancel()
+
+	r.RegisterBackgroundTask("doomed", func(rc *RequestContext) error {
+		return errors.New("nope")
+	})
+	r.QueueBackgroundTask(BackgroundTask{
+		Kind: "doomed",
+		Desc: "doomed task",
+	})
+
+	var task *models.BackgroundTask
+	assert.Eventually(func() bool {
+		withTestConn(dbPool, func(conn *sqlite.Conn) {
+			tasks := models.AllBackgroundTasks(conn)
+			if len(tasks) == 1 {
+				task = tasks[0]
+			}
+		})
+		return task != nil && task.GaveUpAt != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.EqualValues(backgroundTaskMaxAttempts, task.Attempts)
+	assert.EqualValues("nope", *task.LastError)
+
+	// tasks that were given up on aren't resumed
+	withTestConn(dbPool, func(conn *sqlite.Conn) {
+		assert.Empty(models.PendingBackgroundTasks(conn))
+	})
+}
+
+func Test_BackgroundTaskResume(t *testing.T) {
+	assert := assert.New(t)
+	shortenBackgroundTaskDelays(t)
+
+	dbPool := openTestDB(t, "bgtasks-resume")
+	defer dbPool.Close()
+
+	// the first instance gets shut down while the task is waiting to be retried
+	{
+		r := newTestRouter(t, dbPool)
+		failed := make(chan struct{})
+		r.RegisterBackgroundTask("sync", func(rc *RequestContext) error {
+			defer close(failed)
+			return errors.New("offline")
+		})
+		r.QueueBackgroundTask(BackgroundTask{
+			Kind:   "sync",
+			Params: map[string]interface{}{"gameId": 123},
+			Desc:   "sync game",
+		})
+		<-failed
+		r.initiateShutdown()
+
+		assert.Eventually(func() bool {
+			return r.NumInflightBackgroundTasks() == 0
+		}, 2*time.Second, 10*time.Millisecond)
+	}
+
+	var saved *models.BackgroundTask
+	withTestConn(dbPool, func(conn *sqlite.Conn) {
+		tasks := models.PendingBackgroundTasks(conn)
+		if assert.Len(tasks, 1) {
+			saved = tasks[0]
+		}
+	})
+	if saved == nil {
+		return
+	}
+	assert.EqualValues(1, saved.Attempts)
+	assert.EqualValues("offline", *saved.LastError)
+	assert.NotNil(saved.NextAttemptAt)
+
+	// the next instance picks it up where it was left
+	{
+		r := newTestRouter(t, dbPool)
+		defer r.backgroundCancel()
+
+		paramsChan := make(chan string, 1)
+		r.RegisterBackgroundTask("sync", func(rc *RequestContext) error {
+			paramsChan <- string(*rc.Params)
+			return nil
+		})
+		assert.NoError(r.ResumeBackgroundTasks())
+
+		select {
+		case params := <-paramsChan:
+			assert.EqualValues(`{"gameId":123}`, params)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for background task to be resumed")
+		}
+
+		assert.Eventually(func() bool {
+			var task *models.BackgroundTask
+			withTestConn(dbPool, func(conn *sqlite.Conn) {
+				task = models.BackgroundTaskByID(conn, saved.ID)
+			})
+			return task == nil
+		}, 2*time.Second, 10*time.Millisecond)
+	}
+}
@@ -0,0 +1,74 @@
+// Package bandwidth combines bandwidth limits set from different places
+// (the user, the download schedule, etc.) and applies the strictest one.
+//
+// Throttling is done by httpkit for all connections the process makes,
+// so those limits are global. Limits that only apply to a single download
+// are set with a Throttle.
+package bandwidth
+
+import (
+	"sync"
+
+	"github.com/efarrer/iothrottler"
+	"github.com/itchio/httpkit/timeout"
+)
+
+// A Source is something that wants to limit bandwidth.
+type Source string
+
+const (
+	// SourceUser is set with Network.SetBandwidthThrottle, or --throttle
+	SourceUser Source = "user"
+	// SourceSchedule is set by Downloads.Drive, according to the download schedule
+	SourceSchedule Source = "schedule"
+)
+
+var limits = make(map[Source]int64)
+var lock sync.Mutex
+
+// Set limits bandwidth to the given rate in kbps, on behalf of source.
+// A rate of 0 (or less) clears the source's limit.
+func Set(source Source, kbps int64) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if kbps <= 0 {
+		delete(limits, source)
+	} else {
+		limits[source] = kbps
+	}
+
+	rate := effective()
+	if rate == 0 {
+		timeout.ThrottlerPool.SetBandwidth(iothrottler.Unlimited)
+	} else {
+		timeout.ThrottlerPool.SetBandwidth(iothrottler.Bandwidth(rate) * iothrottler.Kbps)
+	}
+}
+
+// Effective returns the rate currently applied, in kbps, or 0 if unlimited.
+func Effective() int64 {
+	lock.Lock()
+	defer lock.Unlock()
+	return effective()
+}
+
+// Min returns the strictest of two rates in kbps, where 0 means unlimited.
+func Min(a, b int64) int64 {
+	if a <= 0 {
+		return b
+	}
+	if b <= 0 || a < b {
+		return a
+	}
+	return b
+}
+
+// caller must hold lock
+func effective() int64 {
+	var rate int64
+	for _, limit := range limits {
+		rate = Min(rate, limit)
+	}
+	return rate
+}
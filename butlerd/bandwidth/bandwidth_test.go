@@ -0,0 +1,36 @@
+package bandwidth_test
+
+import (
+	"testing"
+
+	"github.com/itchio/butler/butlerd/bandwidth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.EqualValues(0, bandwidth.Effective())
+
+	bandwidth.Set(bandwidth.SourceUser, 800)
+	assert.EqualValues(800, bandwidth.Effective())
+
+	bandwidth.Set(bandwidth.SourceSchedule, 200)
+	assert.EqualValues(200, bandwidth.Effective(), "strictest limit wins")
+
+	bandwidth.Set(bandwidth.SourceSchedule, 0)
+	assert.EqualValues(800, bandwidth.Effective(), "clearing a source restores the others")
+
+	bandwidth.Set(bandwidth.SourceUser, 0)
+	assert.EqualValues(0, bandwidth.Effective())
+}
+
+func TestMin(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.EqualValues(0, bandwidth.Min(0, 0))
+	assert.EqualValues(10, bandwidth.Min(0, 10))
+	assert.EqualValues(10, bandwidth.Min(10, 0))
+	assert.EqualValues(10, bandwidth.Min(10, 20))
+	assert.EqualValues(10, bandwidth.Min(20, 10))
+}
@@ -0,0 +1,68 @@
+package bandwidth
+
+import (
+	"net/http"
+	"sync"
+)
+
+// Some code opens URLs with eos's default HTTP client, with no way of
+// passing it another one, like wharf's archive healer. Routes let a
+// throttle apply to it anyway.
+var routes = make(map[string]*Throttle)
+var routesLock sync.Mutex
+
+// Routed makes client send requests for URLs passed to Throttle.Route,
+// and the redirects they lead to, through that throttle's connections.
+// It's meant for the process-wide client eos uses by default.
+func Routed(client *http.Client) {
+	inner := client.Transport
+	if inner == nil {
+		inner = http.DefaultTransport
+	}
+	client.Transport = &router{inner: inner}
+}
+
+// Route throttles requests for url made with a Routed client, until the
+// returned function is called.
+func (t *Throttle) Route(url string) func() {
+	routesLock.Lock()
+	defer routesLock.Unlock()
+	routes[url] = t
+
+	return func() {
+		routesLock.Lock()
+		defer routesLock.Unlock()
+		if routes[url] == t {
+			delete(routes, url)
+		}
+	}
+}
+
+type router struct {
+	inner http.RoundTripper
+}
+
+func (r *router) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t := routeFor(req); t != nil {
+		return t.HTTPClient().Transport.RoundTrip(req)
+	}
+	return r.inner.RoundTrip(req)
+}
+
+// routeFor returns the throttle for req, or for the request
+// that redirected to it, if any.
+func routeFor(req *http.Request) *Throttle {
+	routesLock.Lock()
+	defer routesLock.Unlock()
+
+	for req != nil {
+		if t, ok := routes[req.URL.String()]; ok {
+			return t
+		}
+		if req.Response == nil {
+			break
+		}
+		req = req.Response.Request
+	}
+	return nil
+}
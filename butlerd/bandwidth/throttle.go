@@ -0,0 +1,106 @@
+package bandwidth
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/itchio/httpkit/eos/option"
+	"github.com/itchio/httpkit/timeout"
+	"golang.org/x/time/rate"
+)
+
+// Reads on throttled connections are at most that many bytes, so that
+// they never wait for more than the limiter allows at once.
+const throttleBurst = 32 * 1024
+
+// A Throttle limits the bandwidth of a single download, on top of the
+// process-wide limits set with Set: connections made with its HTTP client
+// go through both, so the strictest one wins.
+type Throttle struct {
+	limiter *rate.Limiter
+	onRead  func(n int64)
+
+	clientOnce sync.Once
+	client     *http.Client
+}
+
+// NewThrottle returns an unlimited throttle.
+func NewThrottle() *Throttle {
+	return &Throttle{
+		limiter: rate.NewLimiter(rate.Inf, throttleBurst),
+	}
+}
+
+// Set limits the download to the given rate in kbps.
+// A rate of 0 (or less) makes it unlimited.
+func (t *Throttle) Set(kbps int64) {
+	if kbps <= 0 {
+		t.limiter.SetLimit(rate.Inf)
+	} else {
+		// 1 kbps is 128 bytes per second, like iothrottler's
+		t.limiter.SetLimit(rate.Limit(kbps * 128))
+	}
+}
+
+// OnRead sets a function that's called with the number of bytes read
+// from the throttle's connections, after each read. It must be set
+// before the HTTP client is used.
+func (t *Throttle) OnRead(f func(n int64)) {
+	t.onRead = f
+}
+
+// HTTPClient returns a client whose connections are throttled.
+// It follows redirects the same way eos does.
+func (t *Throttle) HTTPClient() *http.Client {
+	t.clientOnce.Do(func() {
+		client := timeout.NewDefaultClient()
+		transport := client.Transport.(*http.Transport)
+		dial := transport.Dial
+		transport.Dial = func(network, addr string) (net.Conn, error) {
+			conn, err := dial(network, addr)
+			if err != nil {
+				return nil, err
+			}
+			return &throttledConn{Conn: conn, limiter: t.limiter, onRead: t.onRead}, nil
+		}
+		client.CheckRedirect = option.DefaultSettings().HTTPClient.CheckRedirect
+		t.client = client
+	})
+	return t.client
+}
+
+// Close closes the HTTP client's idle connections, once the download is done.
+// It doesn't wait for them to be closed: connections with a read in
+// progress (as idle keep-alive connections do) only close once that
+// read returns, which may take up to the idle timeout.
+func (t *Throttle) Close() {
+	// makes sure the client isn't being created concurrently
+	t.clientOnce.Do(func() {})
+	if t.client != nil {
+		go t.client.CloseIdleConnections()
+	}
+}
+
+type throttledConn struct {
+	net.Conn
+	limiter *rate.Limiter
+	onRead  func(n int64)
+}
+
+func (c *throttledConn) Read(b []byte) (int, error) {
+	if len(b) > throttleBurst {
+		b = b[:throttleBurst]
+	}
+
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		if c.onRead != nil {
+			c.onRead(int64(n))
+		}
+		// can only fail if n is larger than the burst, which it isn't
+		_ = c.limiter.WaitN(context.Background(), n)
+	}
+	return n, err
+}
@@ -0,0 +1,102 @@
+package bandwidth
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottle(t *testing.T) {
+	assert := assert.New(t)
+
+	payload := bytes.Repeat([]byte{0x42}, 48*1024)
+	var read int64
+	download := func(throttle *Throttle) time.Duration {
+		server, client := net.Pipe()
+		go func() {
+			server.Write(payload)
+			server.Close()
+		}()
+
+		start := time.Now()
+		conn := &throttledConn{Conn: client, limiter: throttle.limiter, onRead: throttle.onRead}
+		body, err := ioutil.ReadAll(conn)
+		assert.NoError(err)
+		assert.EqualValues(len(payload), len(body))
+		return time.Since(start)
+	}
+
+	fast := NewThrottle()
+	fast.OnRead(func(n int64) {
+		read += n
+	})
+	slow := NewThrottle()
+	slow.Set(128) // 16KiB/s
+
+	assert.True(download(fast) < 1*time.Second, "unlimited throttle is fast")
+	assert.EqualValues(len(payload), read, "reads are reported")
+	assert.True(download(slow) >= 1*time.Second, "limited throttle is slow")
+	assert.EqualValues(0, Effective(), "throttles don't set global limits")
+
+	slow.Set(0)
+	assert.True(download(slow) < 1*time.Second, "throttles can be lifted")
+
+	assert.NotNil(slow.HTTPClient().CheckRedirect, "follows redirects like eos")
+}
+
+type countingTransport struct {
+	inner http.RoundTripper
+	count int
+}
+
+func (ct *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ct.count++
+	return ct.inner.RoundTrip(req)
+}
+
+func TestRoute(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/archive.zip", http.StatusFound)
+			return
+		}
+		w.Write([]byte("archive"))
+	}))
+	defer server.Close()
+
+	inner := &countingTransport{inner: http.DefaultTransport}
+	client := &http.Client{Transport: inner}
+	Routed(client)
+
+	get := func(url string) {
+		res, err := client.Get(url)
+		if assert.NoError(err) {
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(err)
+			assert.EqualValues("archive", string(body))
+			res.Body.Close()
+		}
+	}
+
+	throttle := NewThrottle()
+	release := throttle.Route(server.URL + "/redirect")
+
+	get(server.URL + "/redirect")
+	assert.EqualValues(0, inner.count, "routed requests and their redirects go through the throttle")
+
+	get(server.URL + "/archive.zip")
+	assert.EqualValues(1, inner.count, "other requests don't")
+
+	release()
+	get(server.URL + "/redirect")
+	assert.EqualValues(3, inner.count, "released routes don't either")
+	throttle.Close()
+}
@@ -90,12 +90,18 @@ func (s *Server) serveTCPKeepAlive(ctx context.Context, params ServeTCPParams) e
 }
 
 func (s *Server) handleTCPConn(parentCtx context.Context, params ServeTCPParams, tcpConn net.Conn) error {
-	gh := newGatedHandler(params.Handler, params.Secret)
+	return s.handleConn(parentCtx, params.Handler, params.Secret, jsonrpc2.NewRwcTransport(tcpConn))
+}
+
+// handleConn serves JSON-RPC 2.0 over any transport, gated by the secret
+// handshake, and returns when the connection is closed.
+func (s *Server) handleConn(parentCtx context.Context, handler jsonrpc2.Handler, secret string, transport jsonrpc2.Transport) error {
+	gh := newGatedHandler(handler, secret)
 
 	ctx, cancel := context.WithCancel(parentCtx)
 	defer cancel()
 
-	conn := jsonrpc2.NewConn(ctx, jsonrpc2.NewRwcTransport(tcpConn), gh)
+	conn := jsonrpc2.NewConn(ctx, transport, gh)
 	<-conn.DisconnectNotify()
 
 	return nil
@@ -0,0 +1,168 @@
+// Package client is a typed Go client for butlerd.
+//
+// Methods for each request, handlers for requests butlerd makes to
+// the client and notification channels are generated by generous from
+// butlerd's types, see client_generated.go.
+package client
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/helloeave/json"
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/jsonrpc2"
+	"github.com/pkg/errors"
+)
+
+const dialTimeout = 5 * time.Second
+
+// How many notifications of a given type can be queued before
+// handling them blocks.
+const notificationBufferSize = 64
+
+type requestHandler func(params json.RawMessage) (interface{}, error)
+type notificationHandler func(params json.RawMessage)
+
+type Client struct {
+	conn jsonrpc2.Conn
+
+	requestHandlers      map[string]requestHandler
+	notificationHandlers map[string][]notificationHandler
+	lock                 sync.RWMutex
+}
+
+var _ jsonrpc2.Handler = (*Client)(nil)
+
+// Connect uses an already-established connection to butlerd (TCP, unix socket,
+// named pipe, etc.) and authenticates with the given secret, unless it's empty.
+func Connect(ctx context.Context, rwc jsonrpc2.ReadWriteClose, secret string) (*Client, error) {
+	c := &Client{
+		requestHandlers:      make(map[string]requestHandler),
+		notificationHandlers: make(map[string][]notificationHandler),
+	}
+	c.conn = jsonrpc2.NewConn(ctx, jsonrpc2.NewRwcTransport(rwc), c)
+
+	if secret != "" {
+		_, err := c.MetaAuthenticate(butlerd.MetaAuthenticateParams{
+			Secret: secret,
+		})
+		if err != nil {
+			c.Close()
+			return nil, errors.WithMessage(err, "authenticating")
+		}
+	}
+	return c, nil
+}
+
+// Dial connects to butlerd over TCP.
+func Dial(ctx context.Context, address string, secret string) (*Client, error) {
+	netConn, err := net.DialTimeout("tcp", address, dialTimeout)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return Connect(ctx, netConn, secret)
+}
+
+// DialLocal connects to butlerd over a unix socket, or a named pipe on Windows.
+func DialLocal(ctx context.Context, path string, secret string) (*Client, error) {
+	netConn, err := dialLocal(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return Connect(ctx, netConn, secret)
+}
+
+// Close closes the connection. Pending calls fail.
+func (c *Client) Close() {
+	c.conn.Close()
+}
+
+// Done is closed when the connection is closed. Notification channels are
+// never closed, so receivers should also select on Done.
+func (c *Client) Done() <-chan struct{} {
+	return c.conn.Context().Done()
+}
+
+// Conn returns the underlying JSON-RPC 2.0 connection.
+func (c *Client) Conn() jsonrpc2.Conn {
+	return c.conn
+}
+
+func (c *Client) call(method string, params interface{}, result interface{}) error {
+	return c.conn.Call(method, params, result)
+}
+
+func (c *Client) handleRequest(method string, h requestHandler) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.requestHandlers[method] = h
+}
+
+func (c *Client) handleNotification(method string, h notificationHandler) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.notificationHandlers[method] = append(c.notificationHandlers[method], h)
+}
+
+func (c *Client) HandleRequest(conn jsonrpc2.Conn, req jsonrpc2.Request) (interface{}, error) {
+	c.lock.RLock()
+	h, ok := c.requestHandlers[req.Method]
+	c.lock.RUnlock()
+
+	if !ok {
+		return nil, &jsonrpc2.Error{
+			Code:    jsonrpc2.CodeMethodNotFound,
+			Message: "Method not found: " + req.Method,
+		}
+	}
+
+	var params json.RawMessage
+	if req.Params != nil {
+		params = *req.Params
+	}
+
+	res, err := h(params)
+	if err != nil {
+		if rpcErr, ok := err.(*jsonrpc2.Error); ok {
+			return nil, rpcErr
+		}
+		return nil, &jsonrpc2.Error{
+			Code:    jsonrpc2.CodeInternalError,
+			Message: err.Error(),
+		}
+	}
+	return res, nil
+}
+
+func (c *Client) HandleNotification(conn jsonrpc2.Conn, notif jsonrpc2.Notification) {
+	c.lock.RLock()
+	handlers := c.notificationHandlers[notif.Method]
+	c.lock.RUnlock()
+
+	var params json.RawMessage
+	if notif.Params != nil {
+		params = *notif.Params
+	}
+
+	for _, h := range handlers {
+		h(params)
+	}
+}
+
+func decodeParams(raw json.RawMessage, params interface{}) error {
+	if raw == nil {
+		return nil
+	}
+
+	err := json.Unmarshal(raw, params)
+	if err != nil {
+		return &jsonrpc2.Error{
+			Code:    jsonrpc2.CodeParseError,
+			Message: err.Error(),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,1428 @@
+// Code generated by generous; DO NOT EDIT.
+
+package client
+
+import (
+	"github.com/helloeave/json"
+
+	"github.com/itchio/butler/butlerd"
+)
+
+//==============================
+// Utilities
+//==============================
+
+// MetaAuthenticate performs a Meta.Authenticate request.
+func (c *Client) MetaAuthenticate(params butlerd.MetaAuthenticateParams) (*butlerd.MetaAuthenticateResult, error) {
+	var result butlerd.MetaAuthenticateResult
+	err := c.call("Meta.Authenticate", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// MetaFlow performs a Meta.Flow request.
+func (c *Client) MetaFlow(params butlerd.MetaFlowParams) (*butlerd.MetaFlowResult, error) {
+	var result butlerd.MetaFlowResult
+	err := c.call("Meta.Flow", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// MetaShutdown performs a Meta.Shutdown request.
+func (c *Client) MetaShutdown(params butlerd.MetaShutdownParams) (*butlerd.MetaShutdownResult, error) {
+	var result butlerd.MetaShutdownResult
+	err := c.call("Meta.Shutdown", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// MetaIntrospect performs a Meta.Introspect request.
+func (c *Client) MetaIntrospect(params butlerd.MetaIntrospectParams) (*butlerd.MetaIntrospectResult, error) {
+	var result butlerd.MetaIntrospectResult
+	err := c.call("Meta.Introspect", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// MetaFlowEstablishedNotifications returns a channel receiving all MetaFlowEstablished notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) MetaFlowEstablishedNotifications() <-chan butlerd.MetaFlowEstablishedNotification {
+	ch := make(chan butlerd.MetaFlowEstablishedNotification, notificationBufferSize)
+	c.handleNotification("MetaFlowEstablished", func(raw json.RawMessage) {
+		var params butlerd.MetaFlowEstablishedNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// VersionGet performs a Version.Get request.
+func (c *Client) VersionGet(params butlerd.VersionGetParams) (*butlerd.VersionGetResult, error) {
+	var result butlerd.VersionGetResult
+	err := c.call("Version.Get", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// NetworkSetSimulateOffline performs a Network.SetSimulateOffline request.
+func (c *Client) NetworkSetSimulateOffline(params butlerd.NetworkSetSimulateOfflineParams) (*butlerd.NetworkSetSimulateOfflineResult, error) {
+	var result butlerd.NetworkSetSimulateOfflineResult
+	err := c.call("Network.SetSimulateOffline", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// NetworkSetBandwidthThrottle performs a Network.SetBandwidthThrottle request.
+func (c *Client) NetworkSetBandwidthThrottle(params butlerd.NetworkSetBandwidthThrottleParams) (*butlerd.NetworkSetBandwidthThrottleResult, error) {
+	var result butlerd.NetworkSetBandwidthThrottleResult
+	err := c.call("Network.SetBandwidthThrottle", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// NetworkGetStatus performs a Network.GetStatus request.
+func (c *Client) NetworkGetStatus(params butlerd.NetworkGetStatusParams) (*butlerd.NetworkGetStatusResult, error) {
+	var result butlerd.NetworkGetStatusResult
+	err := c.call("Network.GetStatus", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+//==============================
+// Miscellaneous
+//==============================
+
+// DownloadsDriveProgressNotifications returns a channel receiving all Downloads.Drive.Progress notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) DownloadsDriveProgressNotifications() <-chan butlerd.DownloadsDriveProgressNotification {
+	ch := make(chan butlerd.DownloadsDriveProgressNotification, notificationBufferSize)
+	c.handleNotification("Downloads.Drive.Progress", func(raw json.RawMessage) {
+		var params butlerd.DownloadsDriveProgressNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// DownloadsDriveStartedNotifications returns a channel receiving all Downloads.Drive.Started notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) DownloadsDriveStartedNotifications() <-chan butlerd.DownloadsDriveStartedNotification {
+	ch := make(chan butlerd.DownloadsDriveStartedNotification, notificationBufferSize)
+	c.handleNotification("Downloads.Drive.Started", func(raw json.RawMessage) {
+		var params butlerd.DownloadsDriveStartedNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// DownloadsDriveErroredNotifications returns a channel receiving all Downloads.Drive.Errored notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) DownloadsDriveErroredNotifications() <-chan butlerd.DownloadsDriveErroredNotification {
+	ch := make(chan butlerd.DownloadsDriveErroredNotification, notificationBufferSize)
+	c.handleNotification("Downloads.Drive.Errored", func(raw json.RawMessage) {
+		var params butlerd.DownloadsDriveErroredNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// DownloadsDriveFinishedNotifications returns a channel receiving all Downloads.Drive.Finished notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) DownloadsDriveFinishedNotifications() <-chan butlerd.DownloadsDriveFinishedNotification {
+	ch := make(chan butlerd.DownloadsDriveFinishedNotification, notificationBufferSize)
+	c.handleNotification("Downloads.Drive.Finished", func(raw json.RawMessage) {
+		var params butlerd.DownloadsDriveFinishedNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// DownloadsDriveDiscardedNotifications returns a channel receiving all Downloads.Drive.Discarded notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) DownloadsDriveDiscardedNotifications() <-chan butlerd.DownloadsDriveDiscardedNotification {
+	ch := make(chan butlerd.DownloadsDriveDiscardedNotification, notificationBufferSize)
+	c.handleNotification("Downloads.Drive.Discarded", func(raw json.RawMessage) {
+		var params butlerd.DownloadsDriveDiscardedNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// DownloadsDriveNetworkStatusNotifications returns a channel receiving all Downloads.Drive.NetworkStatus notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) DownloadsDriveNetworkStatusNotifications() <-chan butlerd.DownloadsDriveNetworkStatusNotification {
+	ch := make(chan butlerd.DownloadsDriveNetworkStatusNotification, notificationBufferSize)
+	c.handleNotification("Downloads.Drive.NetworkStatus", func(raw json.RawMessage) {
+		var params butlerd.DownloadsDriveNetworkStatusNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// LogNotifications returns a channel receiving all Log notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) LogNotifications() <-chan butlerd.LogNotification {
+	ch := make(chan butlerd.LogNotification, notificationBufferSize)
+	c.handleNotification("Log", func(raw json.RawMessage) {
+		var params butlerd.LogNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+//==============================
+// Profile
+//==============================
+
+// ProfileList performs a Profile.List request.
+func (c *Client) ProfileList(params butlerd.ProfileListParams) (*butlerd.ProfileListResult, error) {
+	var result butlerd.ProfileListResult
+	err := c.call("Profile.List", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ProfileLoginWithPassword performs a Profile.LoginWithPassword request.
+func (c *Client) ProfileLoginWithPassword(params butlerd.ProfileLoginWithPasswordParams) (*butlerd.ProfileLoginWithPasswordResult, error) {
+	var result butlerd.ProfileLoginWithPasswordResult
+	err := c.call("Profile.LoginWithPassword", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ProfileLoginWithAPIKey performs a Profile.LoginWithAPIKey request.
+func (c *Client) ProfileLoginWithAPIKey(params butlerd.ProfileLoginWithAPIKeyParams) (*butlerd.ProfileLoginWithAPIKeyResult, error) {
+	var result butlerd.ProfileLoginWithAPIKeyResult
+	err := c.call("Profile.LoginWithAPIKey", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// OnProfileRequestCaptcha sets the handler for Profile.RequestCaptcha requests, made by butlerd.
+func (c *Client) OnProfileRequestCaptcha(f func(params butlerd.ProfileRequestCaptchaParams) (*butlerd.ProfileRequestCaptchaResult, error)) {
+	c.handleRequest("Profile.RequestCaptcha", func(raw json.RawMessage) (interface{}, error) {
+		var params butlerd.ProfileRequestCaptchaParams
+		err := decodeParams(raw, &params)
+		if err != nil {
+			return nil, err
+		}
+		res, err := f(params)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
+
+// OnProfileRequestTOTP sets the handler for Profile.RequestTOTP requests, made by butlerd.
+func (c *Client) OnProfileRequestTOTP(f func(params butlerd.ProfileRequestTOTPParams) (*butlerd.ProfileRequestTOTPResult, error)) {
+	c.handleRequest("Profile.RequestTOTP", func(raw json.RawMessage) (interface{}, error) {
+		var params butlerd.ProfileRequestTOTPParams
+		err := decodeParams(raw, &params)
+		if err != nil {
+			return nil, err
+		}
+		res, err := f(params)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
+
+// ProfileUseSavedLogin performs a Profile.UseSavedLogin request.
+func (c *Client) ProfileUseSavedLogin(params butlerd.ProfileUseSavedLoginParams) (*butlerd.ProfileUseSavedLoginResult, error) {
+	var result butlerd.ProfileUseSavedLoginResult
+	err := c.call("Profile.UseSavedLogin", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ProfileForget performs a Profile.Forget request.
+func (c *Client) ProfileForget(params butlerd.ProfileForgetParams) (*butlerd.ProfileForgetResult, error) {
+	var result butlerd.ProfileForgetResult
+	err := c.call("Profile.Forget", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ProfileDataPut performs a Profile.Data.Put request.
+func (c *Client) ProfileDataPut(params butlerd.ProfileDataPutParams) (*butlerd.ProfileDataPutResult, error) {
+	var result butlerd.ProfileDataPutResult
+	err := c.call("Profile.Data.Put", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ProfileDataGet performs a Profile.Data.Get request.
+func (c *Client) ProfileDataGet(params butlerd.ProfileDataGetParams) (*butlerd.ProfileDataGetResult, error) {
+	var result butlerd.ProfileDataGetResult
+	err := c.call("Profile.Data.Get", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+//==============================
+// Search
+//==============================
+
+// SearchGames performs a Search.Games request.
+func (c *Client) SearchGames(params butlerd.SearchGamesParams) (*butlerd.SearchGamesResult, error) {
+	var result butlerd.SearchGamesResult
+	err := c.call("Search.Games", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SearchUsers performs a Search.Users request.
+func (c *Client) SearchUsers(params butlerd.SearchUsersParams) (*butlerd.SearchUsersResult, error) {
+	var result butlerd.SearchUsersResult
+	err := c.call("Search.Users", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+//==============================
+// Fetch
+//==============================
+
+// FetchGame performs a Fetch.Game request.
+func (c *Client) FetchGame(params butlerd.FetchGameParams) (*butlerd.FetchGameResult, error) {
+	var result butlerd.FetchGameResult
+	err := c.call("Fetch.Game", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FetchGameRecords performs a Fetch.GameRecords request.
+func (c *Client) FetchGameRecords(params butlerd.FetchGameRecordsParams) (*butlerd.FetchGameRecordsResult, error) {
+	var result butlerd.FetchGameRecordsResult
+	err := c.call("Fetch.GameRecords", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FetchDownloadKey performs a Fetch.DownloadKey request.
+func (c *Client) FetchDownloadKey(params butlerd.FetchDownloadKeyParams) (*butlerd.FetchDownloadKeyResult, error) {
+	var result butlerd.FetchDownloadKeyResult
+	err := c.call("Fetch.DownloadKey", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FetchDownloadKeys performs a Fetch.DownloadKeys request.
+func (c *Client) FetchDownloadKeys(params butlerd.FetchDownloadKeysParams) (*butlerd.FetchDownloadKeysResult, error) {
+	var result butlerd.FetchDownloadKeysResult
+	err := c.call("Fetch.DownloadKeys", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FetchGameUploads performs a Fetch.GameUploads request.
+func (c *Client) FetchGameUploads(params butlerd.FetchGameUploadsParams) (*butlerd.FetchGameUploadsResult, error) {
+	var result butlerd.FetchGameUploadsResult
+	err := c.call("Fetch.GameUploads", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FetchUser performs a Fetch.User request.
+func (c *Client) FetchUser(params butlerd.FetchUserParams) (*butlerd.FetchUserResult, error) {
+	var result butlerd.FetchUserResult
+	err := c.call("Fetch.User", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FetchSale performs a Fetch.Sale request.
+func (c *Client) FetchSale(params butlerd.FetchSaleParams) (*butlerd.FetchSaleResult, error) {
+	var result butlerd.FetchSaleResult
+	err := c.call("Fetch.Sale", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FetchCollection performs a Fetch.Collection request.
+func (c *Client) FetchCollection(params butlerd.FetchCollectionParams) (*butlerd.FetchCollectionResult, error) {
+	var result butlerd.FetchCollectionResult
+	err := c.call("Fetch.Collection", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FetchCollectionGames performs a Fetch.Collection.Games request.
+func (c *Client) FetchCollectionGames(params butlerd.FetchCollectionGamesParams) (*butlerd.FetchCollectionGamesResult, error) {
+	var result butlerd.FetchCollectionGamesResult
+	err := c.call("Fetch.Collection.Games", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FetchProfileCollections performs a Fetch.ProfileCollections request.
+func (c *Client) FetchProfileCollections(params butlerd.FetchProfileCollectionsParams) (*butlerd.FetchProfileCollectionsResult, error) {
+	var result butlerd.FetchProfileCollectionsResult
+	err := c.call("Fetch.ProfileCollections", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FetchProfileGames performs a Fetch.ProfileGames request.
+func (c *Client) FetchProfileGames(params butlerd.FetchProfileGamesParams) (*butlerd.FetchProfileGamesResult, error) {
+	var result butlerd.FetchProfileGamesResult
+	err := c.call("Fetch.ProfileGames", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FetchProfileOwnedKeys performs a Fetch.ProfileOwnedKeys request.
+func (c *Client) FetchProfileOwnedKeys(params butlerd.FetchProfileOwnedKeysParams) (*butlerd.FetchProfileOwnedKeysResult, error) {
+	var result butlerd.FetchProfileOwnedKeysResult
+	err := c.call("Fetch.ProfileOwnedKeys", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FetchCommons performs a Fetch.Commons request.
+func (c *Client) FetchCommons(params butlerd.FetchCommonsParams) (*butlerd.FetchCommonsResult, error) {
+	var result butlerd.FetchCommonsResult
+	err := c.call("Fetch.Commons", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FetchCaves performs a Fetch.Caves request.
+func (c *Client) FetchCaves(params butlerd.FetchCavesParams) (*butlerd.FetchCavesResult, error) {
+	var result butlerd.FetchCavesResult
+	err := c.call("Fetch.Caves", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FetchCave performs a Fetch.Cave request.
+func (c *Client) FetchCave(params butlerd.FetchCaveParams) (*butlerd.FetchCaveResult, error) {
+	var result butlerd.FetchCaveResult
+	err := c.call("Fetch.Cave", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FetchExpireAll performs a Fetch.ExpireAll request.
+func (c *Client) FetchExpireAll(params butlerd.FetchExpireAllParams) (*butlerd.FetchExpireAllResult, error) {
+	var result butlerd.FetchExpireAllResult
+	err := c.call("Fetch.ExpireAll", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+//==============================
+// Install
+//==============================
+
+// GameFindUploads performs a Game.FindUploads request.
+func (c *Client) GameFindUploads(params butlerd.GameFindUploadsParams) (*butlerd.GameFindUploadsResult, error) {
+	var result butlerd.GameFindUploadsResult
+	err := c.call("Game.FindUploads", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// InstallQueue performs a Install.Queue request.
+func (c *Client) InstallQueue(params butlerd.InstallQueueParams) (*butlerd.InstallQueueResult, error) {
+	var result butlerd.InstallQueueResult
+	err := c.call("Install.Queue", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// InstallPlan performs a Install.Plan request.
+func (c *Client) InstallPlan(params butlerd.InstallPlanParams) (*butlerd.InstallPlanResult, error) {
+	var result butlerd.InstallPlanResult
+	err := c.call("Install.Plan", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CavesSetPinned performs a Caves.SetPinned request.
+func (c *Client) CavesSetPinned(params butlerd.CavesSetPinnedParams) (*butlerd.CavesSetPinnedResult, error) {
+	var result butlerd.CavesSetPinnedResult
+	err := c.call("Caves.SetPinned", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CavesSetLaunchOverrides performs a Caves.SetLaunchOverrides request.
+func (c *Client) CavesSetLaunchOverrides(params butlerd.CavesSetLaunchOverridesParams) (*butlerd.CavesSetLaunchOverridesResult, error) {
+	var result butlerd.CavesSetLaunchOverridesResult
+	err := c.call("Caves.SetLaunchOverrides", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// InstallCreateShortcut performs a Install.CreateShortcut request.
+func (c *Client) InstallCreateShortcut(params butlerd.InstallCreateShortcutParams) (*butlerd.InstallCreateShortcutResult, error) {
+	var result butlerd.InstallCreateShortcutResult
+	err := c.call("Install.CreateShortcut", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// InstallPerform performs a Install.Perform request.
+func (c *Client) InstallPerform(params butlerd.InstallPerformParams) (*butlerd.InstallPerformResult, error) {
+	var result butlerd.InstallPerformResult
+	err := c.call("Install.Perform", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// InstallCancel performs a Install.Cancel request.
+func (c *Client) InstallCancel(params butlerd.InstallCancelParams) (*butlerd.InstallCancelResult, error) {
+	var result butlerd.InstallCancelResult
+	err := c.call("Install.Cancel", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UninstallPerform performs a Uninstall.Perform request.
+func (c *Client) UninstallPerform(params butlerd.UninstallPerformParams) (*butlerd.UninstallPerformResult, error) {
+	var result butlerd.UninstallPerformResult
+	err := c.call("Uninstall.Perform", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// InstallVersionSwitchQueue performs a Install.VersionSwitch.Queue request.
+func (c *Client) InstallVersionSwitchQueue(params butlerd.InstallVersionSwitchQueueParams) (*butlerd.InstallVersionSwitchQueueResult, error) {
+	var result butlerd.InstallVersionSwitchQueueResult
+	err := c.call("Install.VersionSwitch.Queue", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// OnInstallVersionSwitchPick sets the handler for InstallVersionSwitchPick requests, made by butlerd.
+func (c *Client) OnInstallVersionSwitchPick(f func(params butlerd.InstallVersionSwitchPickParams) (*butlerd.InstallVersionSwitchPickResult, error)) {
+	c.handleRequest("InstallVersionSwitchPick", func(raw json.RawMessage) (interface{}, error) {
+		var params butlerd.InstallVersionSwitchPickParams
+		err := decodeParams(raw, &params)
+		if err != nil {
+			return nil, err
+		}
+		res, err := f(params)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
+
+// OnPickUpload sets the handler for PickUpload requests, made by butlerd.
+func (c *Client) OnPickUpload(f func(params butlerd.PickUploadParams) (*butlerd.PickUploadResult, error)) {
+	c.handleRequest("PickUpload", func(raw json.RawMessage) (interface{}, error) {
+		var params butlerd.PickUploadParams
+		err := decodeParams(raw, &params)
+		if err != nil {
+			return nil, err
+		}
+		res, err := f(params)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
+
+// ProgressNotifications returns a channel receiving all Progress notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) ProgressNotifications() <-chan butlerd.ProgressNotification {
+	ch := make(chan butlerd.ProgressNotification, notificationBufferSize)
+	c.handleNotification("Progress", func(raw json.RawMessage) {
+		var params butlerd.ProgressNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// TaskStartedNotifications returns a channel receiving all TaskStarted notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) TaskStartedNotifications() <-chan butlerd.TaskStartedNotification {
+	ch := make(chan butlerd.TaskStartedNotification, notificationBufferSize)
+	c.handleNotification("TaskStarted", func(raw json.RawMessage) {
+		var params butlerd.TaskStartedNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// TaskSucceededNotifications returns a channel receiving all TaskSucceeded notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) TaskSucceededNotifications() <-chan butlerd.TaskSucceededNotification {
+	ch := make(chan butlerd.TaskSucceededNotification, notificationBufferSize)
+	c.handleNotification("TaskSucceeded", func(raw json.RawMessage) {
+		var params butlerd.TaskSucceededNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// InstallWoundsNotifications returns a channel receiving all Install.Wounds notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) InstallWoundsNotifications() <-chan butlerd.InstallWoundsNotification {
+	ch := make(chan butlerd.InstallWoundsNotification, notificationBufferSize)
+	c.handleNotification("Install.Wounds", func(raw json.RawMessage) {
+		var params butlerd.InstallWoundsNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// InstallLocationsList performs a Install.Locations.List request.
+func (c *Client) InstallLocationsList(params butlerd.InstallLocationsListParams) (*butlerd.InstallLocationsListResult, error) {
+	var result butlerd.InstallLocationsListResult
+	err := c.call("Install.Locations.List", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// InstallLocationsAdd performs a Install.Locations.Add request.
+func (c *Client) InstallLocationsAdd(params butlerd.InstallLocationsAddParams) (*butlerd.InstallLocationsAddResult, error) {
+	var result butlerd.InstallLocationsAddResult
+	err := c.call("Install.Locations.Add", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// InstallLocationsSetVerifyInstalls performs a Install.Locations.SetVerifyInstalls request.
+func (c *Client) InstallLocationsSetVerifyInstalls(params butlerd.InstallLocationsSetVerifyInstallsParams) (*butlerd.InstallLocationsSetVerifyInstallsResult, error) {
+	var result butlerd.InstallLocationsSetVerifyInstallsResult
+	err := c.call("Install.Locations.SetVerifyInstalls", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// InstallLocationsRemove performs a Install.Locations.Remove request.
+func (c *Client) InstallLocationsRemove(params butlerd.InstallLocationsRemoveParams) (*butlerd.InstallLocationsRemoveResult, error) {
+	var result butlerd.InstallLocationsRemoveResult
+	err := c.call("Install.Locations.Remove", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// InstallLocationsGetByID performs a Install.Locations.GetByID request.
+func (c *Client) InstallLocationsGetByID(params butlerd.InstallLocationsGetByIDParams) (*butlerd.InstallLocationsGetByIDResult, error) {
+	var result butlerd.InstallLocationsGetByIDResult
+	err := c.call("Install.Locations.GetByID", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// InstallLocationsScan performs a Install.Locations.Scan request.
+func (c *Client) InstallLocationsScan(params butlerd.InstallLocationsScanParams) (*butlerd.InstallLocationsScanResult, error) {
+	var result butlerd.InstallLocationsScanResult
+	err := c.call("Install.Locations.Scan", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// InstallLocationsScanYieldNotifications returns a channel receiving all Install.Locations.Scan.Yield notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) InstallLocationsScanYieldNotifications() <-chan butlerd.InstallLocationsScanYieldNotification {
+	ch := make(chan butlerd.InstallLocationsScanYieldNotification, notificationBufferSize)
+	c.handleNotification("Install.Locations.Scan.Yield", func(raw json.RawMessage) {
+		var params butlerd.InstallLocationsScanYieldNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// OnInstallLocationsScanConfirmImport sets the handler for Install.Locations.Scan.ConfirmImport requests, made by butlerd.
+func (c *Client) OnInstallLocationsScanConfirmImport(f func(params butlerd.InstallLocationsScanConfirmImportParams) (*butlerd.InstallLocationsScanConfirmImportResult, error)) {
+	c.handleRequest("Install.Locations.Scan.ConfirmImport", func(raw json.RawMessage) (interface{}, error) {
+		var params butlerd.InstallLocationsScanConfirmImportParams
+		err := decodeParams(raw, &params)
+		if err != nil {
+			return nil, err
+		}
+		res, err := f(params)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
+
+//==============================
+// Downloads
+//==============================
+
+// DownloadsQueue performs a Downloads.Queue request.
+func (c *Client) DownloadsQueue(params butlerd.DownloadsQueueParams) (*butlerd.DownloadsQueueResult, error) {
+	var result butlerd.DownloadsQueueResult
+	err := c.call("Downloads.Queue", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DownloadsPrioritize performs a Downloads.Prioritize request.
+func (c *Client) DownloadsPrioritize(params butlerd.DownloadsPrioritizeParams) (*butlerd.DownloadsPrioritizeResult, error) {
+	var result butlerd.DownloadsPrioritizeResult
+	err := c.call("Downloads.Prioritize", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DownloadsList performs a Downloads.List request.
+func (c *Client) DownloadsList(params butlerd.DownloadsListParams) (*butlerd.DownloadsListResult, error) {
+	var result butlerd.DownloadsListResult
+	err := c.call("Downloads.List", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DownloadsClearFinished performs a Downloads.ClearFinished request.
+func (c *Client) DownloadsClearFinished(params butlerd.DownloadsClearFinishedParams) (*butlerd.DownloadsClearFinishedResult, error) {
+	var result butlerd.DownloadsClearFinishedResult
+	err := c.call("Downloads.ClearFinished", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DownloadsDrive performs a Downloads.Drive request.
+func (c *Client) DownloadsDrive(params butlerd.DownloadsDriveParams) (*butlerd.DownloadsDriveResult, error) {
+	var result butlerd.DownloadsDriveResult
+	err := c.call("Downloads.Drive", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DownloadsDriveCancel performs a Downloads.Drive.Cancel request.
+func (c *Client) DownloadsDriveCancel(params butlerd.DownloadsDriveCancelParams) (*butlerd.DownloadsDriveCancelResult, error) {
+	var result butlerd.DownloadsDriveCancelResult
+	err := c.call("Downloads.Drive.Cancel", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DownloadsRetry performs a Downloads.Retry request.
+func (c *Client) DownloadsRetry(params butlerd.DownloadsRetryParams) (*butlerd.DownloadsRetryResult, error) {
+	var result butlerd.DownloadsRetryResult
+	err := c.call("Downloads.Retry", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DownloadsDiscard performs a Downloads.Discard request.
+func (c *Client) DownloadsDiscard(params butlerd.DownloadsDiscardParams) (*butlerd.DownloadsDiscardResult, error) {
+	var result butlerd.DownloadsDiscardResult
+	err := c.call("Downloads.Discard", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DownloadsPause performs a Downloads.Pause request.
+func (c *Client) DownloadsPause(params butlerd.DownloadsPauseParams) (*butlerd.DownloadsPauseResult, error) {
+	var result butlerd.DownloadsPauseResult
+	err := c.call("Downloads.Pause", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DownloadsResume performs a Downloads.Resume request.
+func (c *Client) DownloadsResume(params butlerd.DownloadsResumeParams) (*butlerd.DownloadsResumeResult, error) {
+	var result butlerd.DownloadsResumeResult
+	err := c.call("Downloads.Resume", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DownloadsGetSchedule performs a Downloads.GetSchedule request.
+func (c *Client) DownloadsGetSchedule(params butlerd.DownloadsGetScheduleParams) (*butlerd.DownloadsGetScheduleResult, error) {
+	var result butlerd.DownloadsGetScheduleResult
+	err := c.call("Downloads.GetSchedule", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DownloadsSetSchedule performs a Downloads.SetSchedule request.
+func (c *Client) DownloadsSetSchedule(params butlerd.DownloadsSetScheduleParams) (*butlerd.DownloadsSetScheduleResult, error) {
+	var result butlerd.DownloadsSetScheduleResult
+	err := c.call("Downloads.SetSchedule", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DownloadsHistory performs a Downloads.History request.
+func (c *Client) DownloadsHistory(params butlerd.DownloadsHistoryParams) (*butlerd.DownloadsHistoryResult, error) {
+	var result butlerd.DownloadsHistoryResult
+	err := c.call("Downloads.History", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DownloadsExport performs a Downloads.Export request.
+func (c *Client) DownloadsExport(params butlerd.DownloadsExportParams) (*butlerd.DownloadsExportResult, error) {
+	var result butlerd.DownloadsExportResult
+	err := c.call("Downloads.Export", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DownloadsImport performs a Downloads.Import request.
+func (c *Client) DownloadsImport(params butlerd.DownloadsImportParams) (*butlerd.DownloadsImportResult, error) {
+	var result butlerd.DownloadsImportResult
+	err := c.call("Downloads.Import", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+//==============================
+// Update
+//==============================
+
+// CheckUpdate performs a CheckUpdate request.
+func (c *Client) CheckUpdate(params butlerd.CheckUpdateParams) (*butlerd.CheckUpdateResult, error) {
+	var result butlerd.CheckUpdateResult
+	err := c.call("CheckUpdate", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GameUpdateAvailableNotifications returns a channel receiving all GameUpdateAvailable notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) GameUpdateAvailableNotifications() <-chan butlerd.GameUpdateAvailableNotification {
+	ch := make(chan butlerd.GameUpdateAvailableNotification, notificationBufferSize)
+	c.handleNotification("GameUpdateAvailable", func(raw json.RawMessage) {
+		var params butlerd.GameUpdateAvailableNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// SnoozeCave performs a SnoozeCave request.
+func (c *Client) SnoozeCave(params butlerd.SnoozeCaveParams) (*butlerd.SnoozeCaveResult, error) {
+	var result butlerd.SnoozeCaveResult
+	err := c.call("SnoozeCave", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+//==============================
+// update
+//==============================
+
+//==============================
+// Launch
+//==============================
+
+// Launch performs a Launch request.
+func (c *Client) Launch(params butlerd.LaunchParams) (*butlerd.LaunchResult, error) {
+	var result butlerd.LaunchResult
+	err := c.call("Launch", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// LaunchRunningNotifications returns a channel receiving all LaunchRunning notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) LaunchRunningNotifications() <-chan butlerd.LaunchRunningNotification {
+	ch := make(chan butlerd.LaunchRunningNotification, notificationBufferSize)
+	c.handleNotification("LaunchRunning", func(raw json.RawMessage) {
+		var params butlerd.LaunchRunningNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// LaunchExitedNotifications returns a channel receiving all LaunchExited notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) LaunchExitedNotifications() <-chan butlerd.LaunchExitedNotification {
+	ch := make(chan butlerd.LaunchExitedNotification, notificationBufferSize)
+	c.handleNotification("LaunchExited", func(raw json.RawMessage) {
+		var params butlerd.LaunchExitedNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// LaunchSessionsList performs a Launch.Sessions.List request.
+func (c *Client) LaunchSessionsList(params butlerd.LaunchSessionsListParams) (*butlerd.LaunchSessionsListResult, error) {
+	var result butlerd.LaunchSessionsListResult
+	err := c.call("Launch.Sessions.List", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// OnAcceptLicense sets the handler for AcceptLicense requests, made by butlerd.
+func (c *Client) OnAcceptLicense(f func(params butlerd.AcceptLicenseParams) (*butlerd.AcceptLicenseResult, error)) {
+	c.handleRequest("AcceptLicense", func(raw json.RawMessage) (interface{}, error) {
+		var params butlerd.AcceptLicenseParams
+		err := decodeParams(raw, &params)
+		if err != nil {
+			return nil, err
+		}
+		res, err := f(params)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
+
+// OnPickManifestAction sets the handler for PickManifestAction requests, made by butlerd.
+func (c *Client) OnPickManifestAction(f func(params butlerd.PickManifestActionParams) (*butlerd.PickManifestActionResult, error)) {
+	c.handleRequest("PickManifestAction", func(raw json.RawMessage) (interface{}, error) {
+		var params butlerd.PickManifestActionParams
+		err := decodeParams(raw, &params)
+		if err != nil {
+			return nil, err
+		}
+		res, err := f(params)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
+
+// OnShellLaunch sets the handler for ShellLaunch requests, made by butlerd.
+func (c *Client) OnShellLaunch(f func(params butlerd.ShellLaunchParams) (*butlerd.ShellLaunchResult, error)) {
+	c.handleRequest("ShellLaunch", func(raw json.RawMessage) (interface{}, error) {
+		var params butlerd.ShellLaunchParams
+		err := decodeParams(raw, &params)
+		if err != nil {
+			return nil, err
+		}
+		res, err := f(params)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
+
+// OnHTMLLaunch sets the handler for HTMLLaunch requests, made by butlerd.
+func (c *Client) OnHTMLLaunch(f func(params butlerd.HTMLLaunchParams) (*butlerd.HTMLLaunchResult, error)) {
+	c.handleRequest("HTMLLaunch", func(raw json.RawMessage) (interface{}, error) {
+		var params butlerd.HTMLLaunchParams
+		err := decodeParams(raw, &params)
+		if err != nil {
+			return nil, err
+		}
+		res, err := f(params)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
+
+// OnURLLaunch sets the handler for URLLaunch requests, made by butlerd.
+func (c *Client) OnURLLaunch(f func(params butlerd.URLLaunchParams) (*butlerd.URLLaunchResult, error)) {
+	c.handleRequest("URLLaunch", func(raw json.RawMessage) (interface{}, error) {
+		var params butlerd.URLLaunchParams
+		err := decodeParams(raw, &params)
+		if err != nil {
+			return nil, err
+		}
+		res, err := f(params)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
+
+// OnAllowSandboxSetup sets the handler for AllowSandboxSetup requests, made by butlerd.
+func (c *Client) OnAllowSandboxSetup(f func(params butlerd.AllowSandboxSetupParams) (*butlerd.AllowSandboxSetupResult, error)) {
+	c.handleRequest("AllowSandboxSetup", func(raw json.RawMessage) (interface{}, error) {
+		var params butlerd.AllowSandboxSetupParams
+		err := decodeParams(raw, &params)
+		if err != nil {
+			return nil, err
+		}
+		res, err := f(params)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
+
+// PrereqsStartedNotifications returns a channel receiving all PrereqsStarted notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) PrereqsStartedNotifications() <-chan butlerd.PrereqsStartedNotification {
+	ch := make(chan butlerd.PrereqsStartedNotification, notificationBufferSize)
+	c.handleNotification("PrereqsStarted", func(raw json.RawMessage) {
+		var params butlerd.PrereqsStartedNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// PrereqsTaskStateNotifications returns a channel receiving all PrereqsTaskState notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) PrereqsTaskStateNotifications() <-chan butlerd.PrereqsTaskStateNotification {
+	ch := make(chan butlerd.PrereqsTaskStateNotification, notificationBufferSize)
+	c.handleNotification("PrereqsTaskState", func(raw json.RawMessage) {
+		var params butlerd.PrereqsTaskStateNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// PrereqsEndedNotifications returns a channel receiving all PrereqsEnded notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) PrereqsEndedNotifications() <-chan butlerd.PrereqsEndedNotification {
+	ch := make(chan butlerd.PrereqsEndedNotification, notificationBufferSize)
+	c.handleNotification("PrereqsEnded", func(raw json.RawMessage) {
+		var params butlerd.PrereqsEndedNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// OnPrereqsFailed sets the handler for PrereqsFailed requests, made by butlerd.
+func (c *Client) OnPrereqsFailed(f func(params butlerd.PrereqsFailedParams) (*butlerd.PrereqsFailedResult, error)) {
+	c.handleRequest("PrereqsFailed", func(raw json.RawMessage) (interface{}, error) {
+		var params butlerd.PrereqsFailedParams
+		err := decodeParams(raw, &params)
+		if err != nil {
+			return nil, err
+		}
+		res, err := f(params)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
+
+//==============================
+// Clean Downloads
+//==============================
+
+// CleanDownloadsSearch performs a CleanDownloads.Search request.
+func (c *Client) CleanDownloadsSearch(params butlerd.CleanDownloadsSearchParams) (*butlerd.CleanDownloadsSearchResult, error) {
+	var result butlerd.CleanDownloadsSearchResult
+	err := c.call("CleanDownloads.Search", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CleanDownloadsApply performs a CleanDownloads.Apply request.
+func (c *Client) CleanDownloadsApply(params butlerd.CleanDownloadsApplyParams) (*butlerd.CleanDownloadsApplyResult, error) {
+	var result butlerd.CleanDownloadsApplyResult
+	err := c.call("CleanDownloads.Apply", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+//==============================
+// Hosts
+//==============================
+
+// HostsList performs a Hosts.List request.
+func (c *Client) HostsList(params butlerd.HostsListParams) (*butlerd.HostsListResult, error) {
+	var result butlerd.HostsListResult
+	err := c.call("Hosts.List", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// HostsAdd performs a Hosts.Add request.
+func (c *Client) HostsAdd(params butlerd.HostsAddParams) (*butlerd.HostsAddResult, error) {
+	var result butlerd.HostsAddResult
+	err := c.call("Hosts.Add", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// HostsRemove performs a Hosts.Remove request.
+func (c *Client) HostsRemove(params butlerd.HostsRemoveParams) (*butlerd.HostsRemoveResult, error) {
+	var result butlerd.HostsRemoveResult
+	err := c.call("Hosts.Remove", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+//==============================
+// System
+//==============================
+
+// SystemStatFS performs a System.StatFS request.
+func (c *Client) SystemStatFS(params butlerd.SystemStatFSParams) (*butlerd.SystemStatFSResult, error) {
+	var result butlerd.SystemStatFSResult
+	err := c.call("System.StatFS", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+//==============================
+// Events
+//==============================
+
+// EventsSubscribe performs a Events.Subscribe request.
+func (c *Client) EventsSubscribe(params butlerd.EventsSubscribeParams) (*butlerd.EventsSubscribeResult, error) {
+	var result butlerd.EventsSubscribeResult
+	err := c.call("Events.Subscribe", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// EventsEventNotifications returns a channel receiving all Events.Event notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) EventsEventNotifications() <-chan butlerd.EventsEventNotification {
+	ch := make(chan butlerd.EventsEventNotification, notificationBufferSize)
+	c.handleNotification("Events.Event", func(raw json.RawMessage) {
+		var params butlerd.EventsEventNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// EventsDroppedNotifications returns a channel receiving all Events.Dropped notifications
+// sent from now on. It is never closed, see Done.
+func (c *Client) EventsDroppedNotifications() <-chan butlerd.EventsDroppedNotification {
+	ch := make(chan butlerd.EventsDroppedNotification, notificationBufferSize)
+	c.handleNotification("Events.Dropped", func(raw json.RawMessage) {
+		var params butlerd.EventsDroppedNotification
+		if decodeParams(raw, &params) != nil {
+			return
+		}
+		select {
+		case ch <- params:
+		case <-c.Done():
+		}
+	})
+	return ch
+}
+
+// EventsUnsubscribe performs a Events.Unsubscribe request.
+func (c *Client) EventsUnsubscribe(params butlerd.EventsUnsubscribeParams) (*butlerd.EventsUnsubscribeResult, error) {
+	var result butlerd.EventsUnsubscribeResult
+	err := c.call("Events.Unsubscribe", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+//==============================
+// Tasks
+//==============================
+
+// TasksList performs a Tasks.List request.
+func (c *Client) TasksList(params butlerd.TasksListParams) (*butlerd.TasksListResult, error) {
+	var result butlerd.TasksListResult
+	err := c.call("Tasks.List", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// TasksCancel performs a Tasks.Cancel request.
+func (c *Client) TasksCancel(params butlerd.TasksCancelParams) (*butlerd.TasksCancelResult, error) {
+	var result butlerd.TasksCancelResult
+	err := c.call("Tasks.Cancel", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+//==============================
+// Test
+//==============================
+
+// TestDoubleTwice performs a Test.DoubleTwice request.
+func (c *Client) TestDoubleTwice(params butlerd.TestDoubleTwiceParams) (*butlerd.TestDoubleTwiceResult, error) {
+	var result butlerd.TestDoubleTwiceResult
+	err := c.call("Test.DoubleTwice", params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// OnTestDouble sets the handler for Test.Double requests, made by butlerd.
+func (c *Client) OnTestDouble(f func(params butlerd.TestDoubleParams) (*butlerd.TestDoubleResult, error)) {
+	c.handleRequest("Test.Double", func(raw json.RawMessage) (interface{}, error) {
+		var params butlerd.TestDoubleParams
+		err := decodeParams(raw, &params)
+		if err != nil {
+			return nil, err
+		}
+		res, err := f(params)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
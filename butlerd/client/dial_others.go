@@ -0,0 +1,11 @@
+//+build !windows
+
+package client
+
+import (
+	"net"
+)
+
+func dialLocal(path string) (net.Conn, error) {
+	return net.DialTimeout("unix", path, dialTimeout)
+}
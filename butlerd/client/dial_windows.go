@@ -0,0 +1,13 @@
+//+build windows
+
+package client
+
+import (
+	"net"
+
+	"github.com/natefinch/npipe"
+)
+
+func dialLocal(path string) (net.Conn, error) {
+	return npipe.DialTimeout(path, dialTimeout)
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/helloeave/json"
+	"github.com/pkg/errors"
+)
+
+// ListenNotification is the line of JSON butlerd prints to stdout once
+// it's ready to accept connections.
+type ListenNotification struct {
+	Type   string `json:"type"`
+	Secret string `json:"secret"`
+
+	TCP *struct {
+		Address string `json:"address"`
+	} `json:"tcp,omitempty"`
+
+	Unix *struct {
+		Path string `json:"path"`
+	} `json:"unix,omitempty"`
+}
+
+// Dial connects to the butlerd instance that sent the notification.
+func (ln *ListenNotification) Dial(ctx context.Context) (*Client, error) {
+	switch {
+	case ln.TCP != nil:
+		return Dial(ctx, ln.TCP.Address, ln.Secret)
+	case ln.Unix != nil:
+		return DialLocal(ctx, ln.Unix.Path, ln.Secret)
+	default:
+		return nil, errors.Errorf("Listen notification has no supported transport")
+	}
+}
+
+// ParseListenNotification returns nil if the line isn't butlerd's listen
+// notification. butlerd may print other lines, JSON or not, which should be
+// ignored.
+func ParseListenNotification(line []byte) *ListenNotification {
+	var ln ListenNotification
+	err := json.Unmarshal(line, &ln)
+	if err != nil || ln.Type != "butlerd/listen-notification" {
+		return nil
+	}
+	return &ln
+}
+
+type StartParams struct {
+	// Path to the butler executable, looked up in $PATH if empty
+	ButlerPath string
+	// Path to butler's database
+	DBPath string
+	// Additional arguments to pass to `butler daemon`, like `--transport unix`
+	Args []string
+	// Where butler's standard error is copied, if non-nil
+	Stderr io.Writer
+	// How long to wait for butlerd to start listening, defaults to 10 seconds
+	Timeout time.Duration
+}
+
+// Start runs `butler daemon` and connects to it. The daemon exits when
+// ctx is done, or when the current process exits.
+func Start(ctx context.Context, params StartParams) (*Client, error) {
+	butlerPath := params.ButlerPath
+	if butlerPath == "" {
+		butlerPath = "butler"
+	}
+	if params.DBPath == "" {
+		return nil, errors.New("DBPath must be set")
+	}
+
+	timeout := params.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+
+	args := []string{
+		"daemon",
+		"--json",
+		"--dbpath", params.DBPath,
+		"--destiny-pid", fmt.Sprintf("%d", os.Getpid()),
+	}
+	args = append(args, params.Args...)
+
+	cmd := exec.CommandContext(ctx, butlerPath, args...)
+	if params.Stderr != nil {
+		cmd.Stderr = params.Stderr
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		return nil, errors.WithMessage(err, "starting butler daemon")
+	}
+	go cmd.Wait()
+
+	lnChan := make(chan *ListenNotification, 1)
+	go func() {
+		s := bufio.NewScanner(stdout)
+		for s.Scan() {
+			if ln := ParseListenNotification(s.Bytes()); ln != nil {
+				lnChan <- ln
+				break
+			}
+		}
+		// keep draining stdout so butler never blocks on it
+		io.Copy(ioutil.Discard, stdout)
+	}()
+
+	select {
+	case ln := <-lnChan:
+		return ln.Dial(ctx)
+	case <-time.After(timeout):
+		cmd.Process.Kill()
+		return nil, errors.Errorf("Timed out waiting for butlerd to listen")
+	case <-ctx.Done():
+		return nil, errors.WithStack(ctx.Err())
+	}
+}
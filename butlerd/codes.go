@@ -25,6 +25,8 @@ var codeMessages = map[Code]string{
 	CodeDatabaseBusy: "The database is busy",
 
 	CodeCantRemoveLocationBecauseOfActiveDownloads: "An install location could not be removed because it has active downloads",
+
+	CodeInsufficientDiskSpace: "There is not enough free disk space in the install location",
 }
 
 func (code Code) RpcErrorMessage() string {
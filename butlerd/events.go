@@ -0,0 +1,216 @@
+package butlerd
+
+import (
+	"log"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/helloeave/json"
+	"github.com/itchio/butler/butlerd/jsonrpc2"
+)
+
+// EventMethodList contains all the notifications that can be
+// subscribed to with `Events.Subscribe`.
+var EventMethodList = []interface{}{
+	"Downloads.Drive.Progress",
+	"Downloads.Drive.Started",
+	"Downloads.Drive.Errored",
+	"Downloads.Drive.Finished",
+	"Downloads.Drive.Discarded",
+	"Downloads.Drive.NetworkStatus",
+	"TaskStarted",
+	"Progress",
+	"LaunchRunning",
+	"LaunchExited",
+}
+
+// EventScope says what the notifications sent while handling a request
+// are about, so that subscribers can filter them.
+type EventScope struct {
+	CaveID     string
+	DownloadID string
+}
+
+// SetEventScope sets the scope of all notifications sent from now on.
+func (rc *RequestContext) SetEventScope(scope EventScope) {
+	rc.eventScope = scope
+}
+
+func (rc *RequestContext) EventScope() EventScope {
+	return rc.eventScope
+}
+
+// EventFilter selects which events a subscription receives. Empty
+// fields match everything.
+type EventFilter struct {
+	Methods     []string
+	CaveIDs     []string
+	DownloadIDs []string
+}
+
+// How many events can be waiting to be sent to a subscriber before it's
+// considered too slow, and unsubscribed.
+const eventQueueSize = 256
+
+type eventSubscription struct {
+	id     string
+	conn   jsonrpc2.Conn
+	filter EventFilter
+
+	// closed when the subscription is removed from the hub
+	queue chan EventsEventNotification
+	// set before queue is closed if the subscriber couldn't keep up
+	dropped bool
+}
+
+// deliver sends queued events in order, so that a slow subscriber
+// never blocks whoever is publishing.
+func (es *eventSubscription) deliver() {
+	// errors mean the subscriber went away, which is cleaned up separately
+	for notif := range es.queue {
+		_ = es.conn.Notify("Events.Event", notif)
+	}
+	if es.dropped {
+		_ = es.conn.Notify("Events.Dropped", EventsDroppedNotification{
+			SubscriptionID: es.id,
+		})
+	}
+}
+
+func (es *eventSubscription) matches(method string, scope EventScope) bool {
+	if len(es.filter.Methods) > 0 && !containsString(es.filter.Methods, method) {
+		return false
+	}
+
+	if len(es.filter.CaveIDs) == 0 && len(es.filter.DownloadIDs) == 0 {
+		return true
+	}
+	if scope.CaveID != "" && containsString(es.filter.CaveIDs, scope.CaveID) {
+		return true
+	}
+	if scope.DownloadID != "" && containsString(es.filter.DownloadIDs, scope.DownloadID) {
+		return true
+	}
+	return false
+}
+
+type eventHub struct {
+	subscriptions map[string]*eventSubscription
+	lock          sync.Mutex
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{
+		subscriptions: make(map[string]*eventSubscription),
+	}
+}
+
+// SubscribeEvents starts forwarding notifications sent on other connections
+// to conn, as `Events.Event`, until UnsubscribeEvents is called or conn is
+// closed. Returns the subscription ID.
+func (r *Router) SubscribeEvents(conn jsonrpc2.Conn, filter EventFilter) string {
+	h := r.events
+	sub := &eventSubscription{
+		id:     uuid.New().String(),
+		conn:   conn,
+		filter: filter,
+		queue:  make(chan EventsEventNotification, eventQueueSize),
+	}
+
+	h.lock.Lock()
+	h.subscriptions[sub.id] = sub
+	h.lock.Unlock()
+
+	go sub.deliver()
+
+	go func() {
+		<-conn.Context().Done()
+		r.UnsubscribeEvents(sub.id)
+	}()
+
+	return sub.id
+}
+
+// UnsubscribeEvents returns false if there was no such subscription.
+func (r *Router) UnsubscribeEvents(subscriptionID string) bool {
+	h := r.events
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return h.remove(subscriptionID)
+}
+
+// remove must be called with the lock held
+func (h *eventHub) remove(subscriptionID string) bool {
+	sub, ok := h.subscriptions[subscriptionID]
+	if !ok {
+		return false
+	}
+	delete(h.subscriptions, subscriptionID)
+	close(sub.queue)
+	return true
+}
+
+func (h *eventHub) publish(origin jsonrpc2.Conn, method string, params interface{}, scope EventScope) {
+	var subs []*eventSubscription
+	h.lock.Lock()
+	for _, sub := range h.subscriptions {
+		// the origin connection already got the notification
+		if sub.conn != origin && sub.matches(method, scope) {
+			subs = append(subs, sub)
+		}
+	}
+	h.lock.Unlock()
+
+	if len(subs) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(params)
+	if err != nil {
+		return
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for _, sub := range subs {
+		if h.subscriptions[sub.id] != sub {
+			// unsubscribed in the meantime
+			continue
+		}
+
+		select {
+		case sub.queue <- EventsEventNotification{
+			SubscriptionID: sub.id,
+			Method:         method,
+			Params:         json.RawMessage(payload),
+			CaveID:         scope.CaveID,
+			DownloadID:     scope.DownloadID,
+		}:
+			// queued
+		default:
+			log.Printf("Events subscriber %s can't keep up, unsubscribing it", sub.id)
+			sub.dropped = true
+			h.remove(sub.id)
+		}
+	}
+}
+
+func isEventMethod(method string) bool {
+	for _, m := range EventMethodList {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, el := range list {
+		if el == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package butlerd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/itchio/butler/butlerd/jsonrpc2"
+	"github.com/stretchr/testify/assert"
+)
+
+type stuckConn struct {
+	ctx     context.Context
+	unstuck chan struct{}
+	notifs  chan string
+}
+
+var _ jsonrpc2.Conn = (*stuckConn)(nil)
+
+func (c *stuckConn) Call(method string, params interface{}, result interface{}) error {
+	return nil
+}
+
+func (c *stuckConn) Notify(method string, params interface{}) error {
+	<-c.unstuck
+	c.notifs <- method
+	return nil
+}
+
+func (c *stuckConn) Context() context.Context {
+	return c.ctx
+}
+
+func (c *stuckConn) Close() {}
+
+func Test_EventsSlowSubscriber(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := NewRouter(nil, nil, nil, nil)
+	slow := &stuckConn{
+		ctx:     ctx,
+		unstuck: make(chan struct{}),
+		notifs:  make(chan string, eventQueueSize*2),
+	}
+	subID := r.SubscribeEvents(slow, EventFilter{})
+
+	// publishing must never block on a subscriber
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		for i := 0; i < eventQueueSize*2; i++ {
+			r.events.publish(nil, "Progress", ProgressNotification{}, EventScope{})
+		}
+	}()
+
+	select {
+	case <-published:
+	case <-time.After(5 * time.Second):
+		t.Fatal("publishing blocked on a slow subscriber")
+	}
+
+	// it fell too far behind, so it got unsubscribed...
+	assert.False(r.UnsubscribeEvents(subID))
+
+	// ...but still gets what was queued before that, then finds out
+	close(slow.unstuck)
+	var methods []string
+	for len(methods) == 0 || methods[len(methods)-1] != "Events.Dropped" {
+		select {
+		case method := <-slow.notifs:
+			methods = append(methods, method)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("never told it was dropped, got %d events", len(methods))
+		}
+	}
+	assert.True(len(methods) >= eventQueueSize)
+	assert.True(len(methods) <= eventQueueSize+2)
+	for _, method := range methods[:len(methods)-1] {
+		assert.EqualValues("Events.Event", method)
+	}
+}
@@ -4,6 +4,7 @@ func (s *scope) assimilateAll() {
 	must(s.assimilate("github.com/itchio/butler/butlerd", "types_launch.go"))
 	must(s.assimilate("github.com/itchio/butler/butlerd", "types.go"))
 	must(s.assimilate("github.com/itchio/butler/manager", "types_host.go"))
+	must(s.assimilate("github.com/itchio/butler/butlerd/generous/spec", "spec.go"))
 
 	must(s.assimilate("github.com/itchio/dash", "types.go"))
 
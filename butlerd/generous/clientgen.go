@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (bc *generousContext) generateGoClient() error {
+	bc.task("Generating go client")
+
+	doc := bc.newGenerousRelativeDoc("../client/client_generated.go")
+
+	doc.line("// Code generated by generous; DO NOT EDIT.")
+	doc.line("")
+	doc.line("package client")
+	doc.line("")
+	doc.line("import (")
+	doc.line("	%q", "github.com/helloeave/json")
+	doc.line("")
+	doc.line("	%q", "github.com/itchio/butler/butlerd")
+	doc.line(")")
+
+	scope := newScope(bc)
+	must(scope.assimilate("github.com/itchio/butler/butlerd", "types.go"))
+
+	for _, category := range scope.categoryList {
+		cat := scope.categories[category]
+		doc.line("")
+		doc.line("//==============================")
+		doc.line("// %s", category)
+		doc.line("//==============================")
+
+		for _, entry := range cat.entries {
+			switch entry.kind {
+			case entryKindParams:
+				ts := asType(entry.gd)
+				funcName := strings.TrimSuffix(ts.Name.Name, "Params")
+				paramsTypeName := fmt.Sprintf("butlerd.%s", ts.Name.Name)
+				resultTypeName := fmt.Sprintf("butlerd.%sResult", funcName)
+				method := entry.name
+
+				switch entry.caller {
+				case callerClient:
+					doc.line("")
+					doc.line("// %s performs a %s request.", funcName, method)
+					doc.line("func (c *Client) %s(params %s) (*%s, error) {", funcName, paramsTypeName, resultTypeName)
+					doc.line("	var result %s", resultTypeName)
+					doc.line("	err := c.call(%#v, params, &result)", method)
+					doc.line("	if err != nil {")
+					doc.line("		return nil, err")
+					doc.line("	}")
+					doc.line("	return &result, nil")
+					doc.line("}")
+				case callerServer:
+					doc.line("")
+					doc.line("// On%s sets the handler for %s requests, made by butlerd.", funcName, method)
+					doc.line("func (c *Client) On%s(f func(params %s) (*%s, error)) {", funcName, paramsTypeName, resultTypeName)
+					doc.line("	c.handleRequest(%#v, func(raw json.RawMessage) (interface{}, error) {", method)
+					doc.line("		var params %s", paramsTypeName)
+					doc.line("		err := decodeParams(raw, &params)")
+					doc.line("		if err != nil {")
+					doc.line("			return nil, err")
+					doc.line("		}")
+					doc.line("		res, err := f(params)")
+					doc.line("		if err != nil {")
+					doc.line("			return nil, err")
+					doc.line("		}")
+					doc.line("		return res, nil")
+					doc.line("	})")
+					doc.line("}")
+				}
+
+			case entryKindNotification:
+				ts := asType(entry.gd)
+				funcName := strings.TrimSuffix(ts.Name.Name, "Notification")
+				paramsTypeName := fmt.Sprintf("butlerd.%s", ts.Name.Name)
+				method := entry.name
+
+				doc.line("")
+				doc.line("// %sNotifications returns a channel receiving all %s notifications", funcName, method)
+				doc.line("// sent from now on. It is never closed, see Done.")
+				doc.line("func (c *Client) %sNotifications() <-chan %s {", funcName, paramsTypeName)
+				doc.line("	ch := make(chan %s, notificationBufferSize)", paramsTypeName)
+				doc.line("	c.handleNotification(%#v, func(raw json.RawMessage) {", method)
+				doc.line("		var params %s", paramsTypeName)
+				doc.line("		if decodeParams(raw, &params) != nil {")
+				doc.line("			return")
+				doc.line("		}")
+				doc.line("		select {")
+				doc.line("		case ch <- params:")
+				doc.line("		case <-c.Done():")
+				doc.line("		}")
+				doc.line("	})")
+				doc.line("	return ch")
+				doc.line("}")
+			}
+		}
+	}
+
+	doc.commit("")
+	doc.write()
+
+	return nil
+}
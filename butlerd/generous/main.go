@@ -23,9 +23,10 @@ func main() {
 	if len(os.Args) < 2 {
 		log.Printf("generous is a documentation & bindings generator for butlerd")
 		log.Printf("")
-		log.Printf("Usage: generous (godocs|ts [OUT])")
+		log.Printf("Usage: generous (godocs|ts [OUT]|openrpc [OUT])")
 		log.Printf("  - godocs: generate directly in the butler sources")
 		log.Printf("  - ts: give a target path to generate")
+		log.Printf("  - openrpc: give a target path to generate, defaults to the one in the butler sources")
 		os.Exit(1)
 	}
 	mode := os.Args[1]
@@ -45,7 +46,9 @@ func main() {
 	case "godocs":
 		must(gc.generateDocs())
 		must(gc.generateGoCode())
+		must(gc.generateGoClient())
 		must(gc.generateSpec())
+		must(gc.generateOpenRPC(gc.openRPCDefaultPath()))
 	case "ts":
 		var tsOut string
 
@@ -81,9 +84,23 @@ func main() {
 		}
 
 		must(gc.generateTsCode(tsOut))
+	case "openrpc":
+		openRPCOut := gc.openRPCDefaultPath()
+		if len(os.Args) > 2 {
+			openRPCOut = os.Args[2]
+		}
+
+		must(gc.generateOpenRPC(openRPCOut))
+	default:
+		log.Printf("generous: unknown mode %q", mode)
+		os.Exit(1)
 	}
 }
 
+func (gc *generousContext) openRPCDefaultPath() string {
+	return filepath.Join(gc.Dir, "spec", "butlerd.openrpc.json")
+}
+
 func getGoPackageDir(pkg string) string {
 	bs, err := exec.Command("go", "list", "-f", "{{ .Dir }}", pkg).Output()
 	must(err)
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"encoding/json"
+	"go/ast"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// See <https://spec.open-rpc.org/>
+const openRPCVersion = "1.3.2"
+
+type openRPCDocument struct {
+	OpenRPC    string             `json:"openrpc"`
+	Info       *openRPCInfo       `json:"info"`
+	Methods    []*openRPCMethod   `json:"methods"`
+	Components *openRPCComponents `json:"components"`
+}
+
+type openRPCInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+type openRPCMethod struct {
+	Name           string                      `json:"name"`
+	Description    string                      `json:"description,omitempty"`
+	Tags           []*openRPCTag               `json:"tags,omitempty"`
+	ParamStructure string                      `json:"paramStructure"`
+	Params         []*openRPCContentDescriptor `json:"params"`
+	// Notifications have no result
+	Result *openRPCContentDescriptor `json:"result,omitempty"`
+	// Either "client" or "server", notifications are always sent by the server
+	Caller string `json:"x-caller"`
+}
+
+type openRPCTag struct {
+	Name string `json:"name"`
+}
+
+type openRPCContentDescriptor struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Schema      *jsonSchema `json:"schema"`
+}
+
+type openRPCComponents struct {
+	Schemas map[string]*jsonSchema `json:"schemas"`
+}
+
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Enum                 []interface{}          `json:"enum,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+}
+
+func (gc *generousContext) generateOpenRPC(outPath string) error {
+	gc.task("Generating OpenRPC document")
+
+	doc := gc.newPathDoc(outPath)
+
+	scope := newScope(gc)
+	scope.assimilateAll()
+
+	schemaRef := func(typeName string) *jsonSchema {
+		return &jsonSchema{Ref: "#/components/schemas/" + typeName}
+	}
+
+	var schemaForType func(e ast.Expr) *jsonSchema
+	schemaForType = func(e ast.Expr) *jsonSchema {
+		switch node := e.(type) {
+		case *ast.Ident:
+			switch node.Name {
+			case "string":
+				return &jsonSchema{Type: "string"}
+			case "int", "int64", "int32", "uint32":
+				return &jsonSchema{Type: "integer"}
+			case "float64":
+				return &jsonSchema{Type: "number"}
+			case "bool":
+				return &jsonSchema{Type: "boolean"}
+			}
+			if scope.findEntry(node.Name) != nil {
+				return schemaRef(node.Name)
+			}
+			log.Printf("OpenRPC: unknown type (%s), allowing anything", node.Name)
+			return &jsonSchema{}
+		case *ast.StarExpr:
+			return schemaForType(node.X)
+		case *ast.SelectorExpr:
+			switch node.Sel.Name {
+			case "Time":
+				return &jsonSchema{Type: "string", Format: "date-time"}
+			case "RawMessage":
+				return &jsonSchema{}
+			}
+			if scope.findEntry(node.Sel.Name) != nil {
+				return schemaRef(node.Sel.Name)
+			}
+			log.Printf("OpenRPC: unknown type (%s), allowing anything", node.Sel.Name)
+			return &jsonSchema{}
+		case *ast.ArrayType:
+			return &jsonSchema{Type: "array", Items: schemaForType(node.Elt)}
+		case *ast.MapType:
+			return &jsonSchema{Type: "object", AdditionalProperties: schemaForType(node.Value)}
+		default:
+			return &jsonSchema{}
+		}
+	}
+
+	schemaForEntry := func(entry *entryInfo) *jsonSchema {
+		s := &jsonSchema{
+			Title:       entry.typeName,
+			Description: strings.Join(entry.doc, "\n"),
+		}
+
+		switch entry.typeKind {
+		case entryTypeKindStruct:
+			s.Type = "object"
+			s.Properties = make(map[string]*jsonSchema)
+			for _, sf := range entry.structFields {
+				fs := schemaForType(sf.typeNode)
+				if len(sf.doc) > 0 && fs.Ref == "" {
+					fs.Description = strings.Join(sf.doc, "\n")
+				}
+				s.Properties[sf.name] = fs
+				if !sf.optional {
+					s.Required = append(s.Required, sf.name)
+				}
+			}
+		case entryTypeKindEnum:
+			base := schemaForType(entry.typeSpec.Type)
+			s.Type = base.Type
+			for _, ev := range entry.enumValues {
+				if s.Type == "string" {
+					value, err := strconv.Unquote(ev.value)
+					must(errors.Wrapf(err, "unquoting value of %s%s", entry.typeName, ev.name))
+					s.Enum = append(s.Enum, value)
+				} else {
+					value, err := strconv.ParseInt(ev.value, 0, 64)
+					must(errors.Wrapf(err, "parsing value of %s%s", entry.typeName, ev.name))
+					s.Enum = append(s.Enum, value)
+				}
+			}
+		case entryTypeKindArrayAlias, entryTypeKindAlias:
+			base := schemaForType(entry.typeSpec.Type)
+			base.Title = s.Title
+			base.Description = s.Description
+			s = base
+		}
+		return s
+	}
+
+	contentDescriptors := func(entry *entryInfo) []*openRPCContentDescriptor {
+		res := []*openRPCContentDescriptor{}
+		for _, sf := range entry.structFields {
+			res = append(res, &openRPCContentDescriptor{
+				Name:        sf.name,
+				Description: strings.Join(sf.doc, "\n"),
+				Required:    !sf.optional,
+				Schema:      schemaForType(sf.typeNode),
+			})
+		}
+		return res
+	}
+
+	d := &openRPCDocument{
+		OpenRPC: openRPCVersion,
+		Info: &openRPCInfo{
+			Title:       "butlerd",
+			Description: "butlerd is butler's JSON-RPC 2.0 service",
+			Version:     "1.0.0",
+		},
+		Methods: []*openRPCMethod{},
+		Components: &openRPCComponents{
+			Schemas: make(map[string]*jsonSchema),
+		},
+	}
+
+	for _, category := range scope.categoryList {
+		cat := scope.categories[category]
+		for _, entry := range cat.entries {
+			switch entry.kind {
+			case entryKindParams:
+				var caller string
+				switch entry.caller {
+				case callerClient:
+					caller = "client"
+				case callerServer:
+					caller = "server"
+				}
+
+				resultTypeName := strings.TrimSuffix(entry.typeName, "Params") + "Result"
+				d.Methods = append(d.Methods, &openRPCMethod{
+					Name:           entry.name,
+					Description:    strings.Join(entry.doc, "\n"),
+					Tags:           []*openRPCTag{{Name: category}},
+					ParamStructure: "by-name",
+					Params:         contentDescriptors(entry),
+					Result: &openRPCContentDescriptor{
+						Name:   resultTypeName,
+						Schema: schemaRef(resultTypeName),
+					},
+					Caller: caller,
+				})
+			case entryKindNotification:
+				d.Methods = append(d.Methods, &openRPCMethod{
+					Name:           entry.name,
+					Description:    strings.Join(entry.doc, "\n"),
+					Tags:           []*openRPCTag{{Name: category}},
+					ParamStructure: "by-name",
+					Params:         contentDescriptors(entry),
+					Caller:         "server",
+				})
+			}
+
+			if entry.typeKind != entryTypeKindInvalid {
+				d.Components.Schemas[entry.typeName] = schemaForEntry(entry)
+			}
+		}
+	}
+
+	js, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	doc.line(string(js))
+	doc.commit("")
+	doc.write()
+
+	return nil
+}
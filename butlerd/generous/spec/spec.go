@@ -1,5 +1,6 @@
 package spec
 
+// Spec describes all requests, notifications and types of the butlerd API
 type Spec struct {
 	Requests      []*RequestSpec      `json:"requests"`
 	Notifications []*NotificationSpec `json:"notifications"`
@@ -7,6 +8,7 @@ type Spec struct {
 	EnumTypes     []*EnumTypeSpec     `json:"enumTypes"`
 }
 
+// RequestSpec describes a request, and who is allowed to make it
 type RequestSpec struct {
 	Method string      `json:"method"`
 	Doc    string      `json:"doc"`
@@ -15,12 +17,14 @@ type RequestSpec struct {
 	Result *StructSpec `json:"result"`
 }
 
+// StructTypeSpec describes a type used in params or results
 type StructTypeSpec struct {
 	Name   string       `json:"name"`
 	Doc    string       `json:"doc"`
 	Fields []*FieldSpec `json:"fields"`
 }
 
+// EnumTypeSpec describes a type that can only take a few values
 type EnumTypeSpec struct {
 	Name   string           `json:"name"`
 	Doc    string           `json:"doc"`
@@ -43,6 +47,7 @@ type FieldSpec struct {
 	Type string `json:"type"`
 }
 
+// NotificationSpec describes a notification
 type NotificationSpec struct {
 	Method string      `json:"method"`
 	Doc    string      `json:"doc"`
@@ -110,5 +110,16 @@ func (gc *generousContext) generateSpec() error {
 	doc.commit("")
 	doc.write()
 
+	// also embed it, so a running butlerd can serve it
+	goDoc := gc.newGenerousRelativeDoc("spec/spec_json.go")
+	goDoc.line("// Code generated by generous; DO NOT EDIT.")
+	goDoc.line("")
+	goDoc.line("package spec")
+	goDoc.line("")
+	goDoc.line("// JSON is the contents of butlerd.json")
+	goDoc.line("const JSON = %q", string(js))
+	goDoc.commit("")
+	goDoc.write()
+
 	return nil
 }
@@ -0,0 +1,222 @@
+package integrate
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/messages"
+	"github.com/itchio/hush"
+	"github.com/itchio/mitch"
+	"github.com/stretchr/testify/assert"
+)
+
+type mirroredGame struct {
+	game    *mitch.Game
+	upload  *mitch.Upload
+	build   *mitch.Build
+	archive []byte
+}
+
+func makeMirroredGame(bi *ButlerInstance, title string) *mirroredGame {
+	store := bi.Server.Store()
+	_developer := store.MakeUser("Mirror Studio")
+	_game := _developer.MakeGame(title)
+	_game.Publish()
+	_upload := _game.MakeUpload("everything")
+	_upload.SetAllPlatforms()
+	_build := _upload.PushBuild(func(ac *mitch.ArchiveContext) {
+		ac.Entry("readme.txt").String(fmt.Sprintf("This is %s", title))
+		ac.Entry("data/level1.dat").String("the first level")
+	})
+
+	bf := _build.GetFile("archive", "default")
+	return &mirroredGame{
+		game:    _game,
+		upload:  _upload,
+		build:   _build,
+		archive: store.CDNFiles[bf.CDNPath()].Contents,
+	}
+}
+
+func (mg *mirroredGame) key() string {
+	return fmt.Sprintf("%d/archive-default", mg.build.ID)
+}
+
+func installMirroredGame(bi *ButlerInstance, mg *mirroredGame) string {
+	queueRes, _ := installMirroredBuild(bi, butlerd.InstallQueueParams{
+		Game: bi.FetchGame(mg.game.ID),
+	})
+	return queueRes.InstallFolder
+}
+
+func installMirroredBuild(bi *ButlerInstance, params butlerd.InstallQueueParams) (*butlerd.InstallQueueResult, *butlerd.InstallPerformResult) {
+	rc := bi.Conn.RequestContext
+	params.InstallLocationID = "tmp"
+
+	queueRes, err := messages.InstallQueue.TestCall(rc, params)
+	must(err)
+
+	performRes, err := messages.InstallPerform.TestCall(rc, butlerd.InstallPerformParams{
+		ID:            queueRes.ID,
+		StagingFolder: queueRes.StagingFolder,
+	})
+	must(err)
+	return queueRes, performRes
+}
+
+func Test_BuildMirror(t *testing.T) {
+	assert := assert.New(t)
+
+	var lock sync.Mutex
+	mirrorFiles := make(map[string][]byte)
+	mirrorHits := make(map[string]int)
+	mirrorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		key := r.URL.Path[1:]
+		contents, ok := mirrorFiles[key]
+		mirrorHits[key]++
+		lock.Unlock()
+
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeContent(w, r, filepath.Base(key), time.Time{}, bytes.NewReader(contents))
+	}))
+	defer mirrorServer.Close()
+
+	bi := newInstance(t, withBuildMirror(mirrorServer.URL))
+	_, _, cancel := bi.Unwrap()
+	defer cancel()
+
+	bi.Authenticate()
+
+	// mirror hit
+	hit := makeMirroredGame(bi, "Mirrored")
+	lock.Lock()
+	mirrorFiles[hit.key()] = hit.archive
+	lock.Unlock()
+
+	installFolder := installMirroredGame(bi, hit)
+	readme, err := ioutil.ReadFile(filepath.Join(installFolder, "readme.txt"))
+	must(err)
+	assert.EqualValues("This is Mirrored", string(readme))
+
+	// every request past the size check is for the actual contents
+	lock.Lock()
+	assert.True(mirrorHits[hit.key()] > 1, "archive should have been read from the mirror")
+	lock.Unlock()
+
+	// size mismatch: the mirror has a stale or broken file, itch.io is used
+	stale := makeMirroredGame(bi, "Stale")
+	lock.Lock()
+	mirrorFiles[stale.key()] = stale.archive[:len(stale.archive)/2]
+	lock.Unlock()
+
+	installFolder = installMirroredGame(bi, stale)
+	readme, err = ioutil.ReadFile(filepath.Join(installFolder, "readme.txt"))
+	must(err)
+	assert.EqualValues("This is Stale", string(readme))
+
+	lock.Lock()
+	assert.EqualValues(1, mirrorHits[stale.key()], "only the size check should hit the mirror")
+	lock.Unlock()
+}
+
+func Test_BuildCache(t *testing.T) {
+	assert := assert.New(t)
+
+	cacheDir, err := ioutil.TempDir("", "butler-build-cache")
+	must(err)
+	defer os.RemoveAll(cacheDir)
+
+	bi := newInstance(t, withBuildCache(cacheDir))
+	_, _, cancel := bi.Unwrap()
+	defer cancel()
+
+	bi.Authenticate()
+
+	mg := makeMirroredGame(bi, "Cached")
+	installFolder := installMirroredGame(bi, mg)
+	readme, err := ioutil.ReadFile(filepath.Join(installFolder, "readme.txt"))
+	must(err)
+	assert.EqualValues("This is Cached", string(readme))
+
+	// the archive was downloaded from itch.io, then cached
+	cached, err := ioutil.ReadFile(filepath.Join(cacheDir, filepath.FromSlash(mg.key())))
+	must(err)
+	assert.EqualValues(mg.archive, cached)
+
+	entries, err := ioutil.ReadDir(filepath.Dir(filepath.Join(cacheDir, filepath.FromSlash(mg.key()))))
+	must(err)
+	assert.Len(entries, 1, "no partial files should be left behind")
+}
+
+func Test_BuildCachePatches(t *testing.T) {
+	assert := assert.New(t)
+
+	cacheDir, err := ioutil.TempDir("", "butler-build-cache")
+	must(err)
+	defer os.RemoveAll(cacheDir)
+
+	bi := newInstance(t, withBuildCache(cacheDir))
+	_, _, cancel := bi.Unwrap()
+	defer cancel()
+
+	bi.Authenticate()
+
+	mg := makeMirroredGame(bi, "Patched")
+	game := bi.FetchGame(mg.game.ID)
+	queueRes, _ := installMirroredBuild(bi, butlerd.InstallQueueParams{
+		Game: game,
+	})
+
+	_build2 := mg.upload.PushBuild(func(ac *mitch.ArchiveContext) {
+		ac.Entry("readme.txt").String("This is Patched, again")
+		ac.Entry("data/level1.dat").String("the first level")
+	})
+
+	_, upgradeRes := installMirroredBuild(bi, butlerd.InstallQueueParams{
+		Game:   game,
+		CaveID: queueRes.CaveID,
+		Upload: bi.FetchUpload(mg.upload.ID),
+		Build:  bi.FetchBuild(_build2.ID),
+	})
+	bi.FindEvent(upgradeRes.Events, hush.InstallEventUpgrade)
+
+	readme, err := ioutil.ReadFile(filepath.Join(queueRes.InstallFolder, "readme.txt"))
+	must(err)
+	assert.EqualValues("This is Patched, again", string(readme))
+
+	// the patch and the signature it was applied against were cached
+	patches, err := filepath.Glob(filepath.Join(cacheDir, fmt.Sprintf("%d", _build2.ID), "patch-*"))
+	must(err)
+	assert.Len(patches, 1)
+	_, err = os.Stat(filepath.Join(cacheDir, fmt.Sprintf("%d", mg.build.ID), "signature-default"))
+	assert.NoError(err)
+
+	// reverting heals from the cached archive, then verifies against itch.io
+	_, revertRes := installMirroredBuild(bi, butlerd.InstallQueueParams{
+		Game:   game,
+		CaveID: queueRes.CaveID,
+		Build:  bi.FetchBuild(mg.build.ID),
+	})
+	bi.FindEvent(revertRes.Events, hush.InstallEventHeal)
+
+	readme, err = ioutil.ReadFile(filepath.Join(queueRes.InstallFolder, "readme.txt"))
+	must(err)
+	assert.EqualValues("This is Patched", string(readme))
+
+	parts, err := filepath.Glob(filepath.Join(cacheDir, "*", "*.part"))
+	must(err)
+	assert.Empty(parts, "no partial files should be left behind")
+}
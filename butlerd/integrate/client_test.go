@@ -0,0 +1,35 @@
+package integrate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Client(t *testing.T) {
+	assert := assert.New(t)
+
+	bi := newInstance(t)
+	_, _, cancel := bi.Unwrap()
+	defer cancel()
+
+	ctx, cancelClient := context.WithCancel(context.Background())
+	defer cancelClient()
+
+	_, err := client.Dial(ctx, bi.Address, "wrong-secret")
+	assert.Error(err, "should not authenticate with the wrong secret")
+
+	c, err := client.Dial(ctx, bi.Address, bi.Secret)
+	must(err)
+	defer c.Close()
+
+	vgr, err := c.VersionGet(butlerd.VersionGetParams{})
+	must(err)
+	assert.NotEmpty(vgr.Version)
+
+	_, err = c.FetchGame(butlerd.FetchGameParams{})
+	assert.Error(err, "should surface validation errors")
+}
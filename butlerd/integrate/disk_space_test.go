@@ -0,0 +1,128 @@
+package integrate
+
+import (
+	"archive/zip"
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/jsonrpc2"
+	"github.com/itchio/butler/butlerd/messages"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DiskSpaceReservation(t *testing.T) {
+	assert := assert.New(t)
+
+	bi := newInstance(t)
+	rc, _, cancel := bi.Unwrap()
+	defer cancel()
+
+	bi.Authenticate()
+
+	tmpPath, err := filepath.Abs("tmp")
+	must(err)
+	statRes, err := messages.SystemStatFS.TestCall(rc, butlerd.SystemStatFSParams{
+		Path: tmpPath,
+	})
+	must(err)
+
+	// each game claims to need 60% of the free space, so there's
+	// only enough room for one of them.
+	claimedSize := uint64(statRes.FreeSize) * 6 / 10
+
+	store := bi.Server.Store()
+	_developer := store.MakeUser("Hoarder Games")
+	queue := func(title string) (*butlerd.InstallQueueResult, error) {
+		_game := _developer.MakeGame(title)
+		_game.Publish()
+		_upload := _game.MakeUpload("everything")
+		_upload.SetAllPlatforms()
+		_upload.SetHostedContents("everything.zip", makeBoastfulZip(claimedSize))
+
+		return messages.InstallQueue.TestCall(rc, butlerd.InstallQueueParams{
+			Game:              bi.FetchGame(_game.ID),
+			InstallLocationID: "tmp",
+			QueueDownload:     true,
+		})
+	}
+
+	first, err := queue("First Come")
+	must(err)
+
+	listRes, err := messages.DownloadsList.TestCall(rc, butlerd.DownloadsListParams{})
+	must(err)
+	assert.Len(listRes.Downloads, 1)
+
+	_, err = queue("First Served")
+	if assert.Error(err) {
+		je := err.(*jsonrpc2.Error)
+		assert.EqualValues(butlerd.CodeInsufficientDiskSpace, je.Code)
+	}
+
+	listRes, err = messages.DownloadsList.TestCall(rc, butlerd.DownloadsListParams{})
+	must(err)
+	assert.Len(listRes.Downloads, 1, "second download should not be queued")
+
+	// discarding the first download releases its reservation
+	_, err = messages.DownloadsDiscard.TestCall(rc, butlerd.DownloadsDiscardParams{
+		DownloadID: first.ID,
+	})
+	must(err)
+
+	second, err := queue("Second Chance")
+	must(err)
+	_, err = messages.DownloadsDiscard.TestCall(rc, butlerd.DownloadsDiscardParams{
+		DownloadID: second.ID,
+	})
+	must(err)
+
+	// queuing an installed game again replaces its download,
+	// so that download's reservation doesn't count
+	_game := _developer.MakeGame("Back Again")
+	_game.Publish()
+	_upload := _game.MakeUpload("everything")
+	_upload.SetAllPlatforms()
+	_upload.SetZipContents()
+	game := bi.FetchGame(_game.ID)
+	installRes := bi.Install(butlerd.InstallQueueParams{
+		Game: game,
+	})
+
+	_upload.SetHostedContents("everything.zip", makeBoastfulZip(claimedSize))
+	requeue := func(queueDownload bool) {
+		_, err := messages.InstallQueue.TestCall(rc, butlerd.InstallQueueParams{
+			Game:              game,
+			CaveID:            installRes.CaveID,
+			Upload:            bi.FetchUpload(_upload.ID),
+			InstallLocationID: "tmp",
+			QueueDownload:     queueDownload,
+		})
+		must(err)
+	}
+	requeue(true)
+	requeue(false)
+}
+
+// makeBoastfulZip returns a zip whose only entry claims to be
+// uncompressedSize bytes, without actually being that large.
+func makeBoastfulZip(uncompressedSize uint64) []byte {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+
+	contents := []byte("not nearly as large as advertised")
+	w, err := zw.CreateRaw(&zip.FileHeader{
+		Name:               "data.bin",
+		Method:             zip.Store,
+		CRC32:              0,
+		CompressedSize64:   uint64(len(contents)),
+		UncompressedSize64: uncompressedSize,
+	})
+	must(err)
+	_, err = w.Write(contents)
+	must(err)
+	must(zw.Close())
+
+	return buf.Bytes()
+}
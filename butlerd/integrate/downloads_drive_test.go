@@ -126,3 +126,202 @@ func Test_DownloadsDrive(t *testing.T) {
 	})
 	must(err)
 }
+
+func Test_DownloadsDriveConcurrent(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping concurrent downloads drive in short mode")
+	}
+
+	assert := assert.New(t)
+
+	bi := newInstance(t)
+	rc, h, cancel := bi.Unwrap()
+	defer cancel()
+
+	bi.Authenticate()
+
+	// slow downloads down enough that they overlap
+	_, err := messages.NetworkSetBandwidthThrottle.TestCall(rc, butlerd.NetworkSetBandwidthThrottleParams{
+		Enabled: true,
+		Rate:    1024,
+	})
+	must(err)
+
+	const numGames = 3
+	const maxConcurrent = 2
+	var caveIDs []string
+	for i := 0; i < numGames; i++ {
+		game := bi.MakeHTMLGame(fmt.Sprintf("Parallel game #%d", i), func(ac *mitch.ArchiveContext) {
+			ac.Entry("index.html").String(fmt.Sprintf("<p>Game %d</p>", i))
+			ac.Entry("data.bin").Random(int64(0xfeed+i), 192*1024)
+		})
+		queueRes, err := messages.InstallQueue.TestCall(rc, butlerd.InstallQueueParams{
+			Game:              game,
+			InstallLocationID: "tmp",
+			QueueDownload:     true,
+		})
+		must(err)
+		caveIDs = append(caveIDs, queueRes.CaveID)
+	}
+
+	var lock sync.Mutex
+	var events []string
+	active := 0
+	maxActive := 0
+	finished := make(map[string]bool)
+	driveDone := make(chan error, 2)
+
+	messages.DownloadsDriveStarted.Register(h, func(params butlerd.DownloadsDriveStartedNotification) {
+		lock.Lock()
+		defer lock.Unlock()
+		events = append(events, "started")
+		active++
+		if active > maxActive {
+			maxActive = active
+		}
+	})
+
+	messages.DownloadsDriveErrored.Register(h, func(params butlerd.DownloadsDriveErroredNotification) {
+		driveDone <- errors.Errorf("Got unexpected DriveErrored for %s", params.Download.ID)
+	})
+
+	messages.DownloadsDriveFinished.Register(h, func(params butlerd.DownloadsDriveFinishedNotification) {
+		lock.Lock()
+		events = append(events, "finished")
+		active--
+		finished[params.Download.CaveID] = true
+		numFinished := len(finished)
+		lock.Unlock()
+
+		if numFinished == numGames {
+			_, err := messages.DownloadsDriveCancel.TestCall(rc, butlerd.DownloadsDriveCancelParams{})
+			must(err)
+		}
+	})
+
+	go func() {
+		_, err := messages.DownloadsDrive.TestCall(rc, butlerd.DownloadsDriveParams{
+			MaxConcurrent: maxConcurrent,
+		})
+		driveDone <- err
+	}()
+
+	select {
+	case err := <-driveDone:
+		assert.NoError(err)
+	case <-time.After(30 * time.Second):
+		must(errors.New("timed out"))
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	bi.Logf("Notifications received: %#v", events)
+	for _, caveID := range caveIDs {
+		assert.True(finished[caveID], "cave %s should have finished downloading", caveID)
+	}
+
+	// the first two downloads ran at the same time, but never more than that
+	assert.EqualValues(maxConcurrent, maxActive)
+	if assert.Len(events, 2*numGames) {
+		assert.EqualValues([]string{"started", "started"}, events[:2])
+	}
+}
+
+func Test_DownloadsDrivePreempt(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping downloads drive preemption in short mode")
+	}
+
+	assert := assert.New(t)
+
+	bi := newInstance(t)
+	rc, h, cancel := bi.Unwrap()
+	defer cancel()
+
+	bi.Authenticate()
+
+	_, err := messages.NetworkSetBandwidthThrottle.TestCall(rc, butlerd.NetworkSetBandwidthThrottleParams{
+		Enabled: true,
+		Rate:    1024,
+	})
+	must(err)
+
+	names := make(map[string]string)
+	var downloadIDs []string
+	for _, name := range []string{"slow", "urgent"} {
+		game := bi.MakeHTMLGame(name, func(ac *mitch.ArchiveContext) {
+			ac.Entry("index.html").String("<p>" + name + "</p>")
+			ac.Entry("data.bin").Random(0xfeed, 384*1024)
+		})
+		queueRes, err := messages.InstallQueue.TestCall(rc, butlerd.InstallQueueParams{
+			Game:              game,
+			InstallLocationID: "tmp",
+			QueueDownload:     true,
+		})
+		must(err)
+		names[queueRes.ID] = name
+		downloadIDs = append(downloadIDs, queueRes.ID)
+	}
+
+	var lock sync.Mutex
+	var events []string
+	driveDone := make(chan error, 2)
+
+	messages.DownloadsDriveStarted.Register(h, func(params butlerd.DownloadsDriveStartedNotification) {
+		lock.Lock()
+		events = append(events, "started "+names[params.Download.ID])
+		first := len(events) == 1
+		lock.Unlock()
+
+		if first {
+			// jump the queue while the first download is in progress
+			_, err := messages.DownloadsPrioritize.TestCall(rc, butlerd.DownloadsPrioritizeParams{
+				DownloadID: downloadIDs[1],
+			})
+			must(err)
+		}
+	})
+
+	messages.DownloadsDriveErrored.Register(h, func(params butlerd.DownloadsDriveErroredNotification) {
+		driveDone <- errors.Errorf("Got unexpected DriveErrored for %s", params.Download.ID)
+	})
+
+	messages.DownloadsDriveFinished.Register(h, func(params butlerd.DownloadsDriveFinishedNotification) {
+		name := names[params.Download.ID]
+		lock.Lock()
+		events = append(events, "finished "+name)
+		lock.Unlock()
+
+		if name == "slow" {
+			_, err := messages.DownloadsDriveCancel.TestCall(rc, butlerd.DownloadsDriveCancelParams{})
+			must(err)
+		}
+	})
+
+	go func() {
+		_, err := messages.DownloadsDrive.TestCall(rc, butlerd.DownloadsDriveParams{
+			MaxConcurrent: 1,
+		})
+		driveDone <- err
+	}()
+
+	select {
+	case err := <-driveDone:
+		assert.NoError(err)
+	case <-time.After(30 * time.Second):
+		must(errors.New("timed out"))
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	// the slow download is stopped to make room for the urgent one,
+	// then picked up again once it's done.
+	assert.EqualValues([]string{
+		"started slow",
+		"started urgent",
+		"finished urgent",
+		"started slow",
+		"finished slow",
+	}, events)
+}
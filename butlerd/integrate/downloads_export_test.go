@@ -0,0 +1,79 @@
+package integrate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/messages"
+	"github.com/itchio/mitch"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DownloadsExportImport(t *testing.T) {
+	assert := assert.New(t)
+
+	bi := newInstance(t)
+	rc, _, cancel := bi.Unwrap()
+	defer cancel()
+
+	bi.Authenticate()
+
+	game := bi.MakeHTMLGame("Fleet", func(ac *mitch.ArchiveContext) {
+		ac.Entry("index.html").String("<p>Everywhere</p>")
+	})
+
+	queueRes, err := messages.InstallQueue.TestCall(rc, butlerd.InstallQueueParams{
+		Game:              game,
+		InstallLocationID: "tmp",
+		QueueDownload:     true,
+	})
+	must(err)
+
+	tmpDir, err := ioutil.TempDir("", "downloads-export-test")
+	must(err)
+	defer os.RemoveAll(tmpDir)
+	manifestPath := filepath.Join(tmpDir, "manifest.json")
+
+	exportRes, err := messages.DownloadsExport.TestCall(rc, butlerd.DownloadsExportParams{
+		Path: manifestPath,
+	})
+	must(err)
+	if assert.Len(exportRes.Manifest.Entries, 1) {
+		entry := exportRes.Manifest.Entries[0]
+		assert.EqualValues(game.ID, entry.GameID)
+		assert.EqualValues(queueRes.Upload.ID, entry.UploadID)
+		assert.EqualValues(queueRes.Build.ID, entry.BuildID)
+		assert.EqualValues(butlerd.DownloadReasonInstall, entry.Reason)
+	}
+
+	_, err = messages.DownloadsDiscard.TestCall(rc, butlerd.DownloadsDiscardParams{
+		DownloadID: queueRes.ID,
+	})
+	must(err)
+
+	importRes, err := messages.DownloadsImport.TestCall(rc, butlerd.DownloadsImportParams{
+		Path:              manifestPath,
+		InstallLocationID: "tmp",
+	})
+	must(err)
+	assert.Len(importRes.Failed, 0)
+	if assert.Len(importRes.Items, 1) {
+		item := importRes.Items[0]
+		assert.EqualValues(game.ID, item.Game.ID)
+		assert.EqualValues(queueRes.Build.ID, item.Build.ID)
+	}
+
+	listRes, err := messages.DownloadsList.TestCall(rc, butlerd.DownloadsListParams{})
+	must(err)
+	if assert.Len(listRes.Downloads, 1) {
+		assert.EqualValues(importRes.Items[0].ID, listRes.Downloads[0].ID)
+	}
+
+	_, err = messages.DownloadsImport.TestCall(rc, butlerd.DownloadsImportParams{
+		Path: filepath.Join(tmpDir, "missing.json"),
+	})
+	assert.Error(err)
+}
@@ -0,0 +1,80 @@
+package integrate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/messages"
+	"github.com/itchio/mitch"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DownloadsHistory(t *testing.T) {
+	assert := assert.New(t)
+
+	bi := newInstance(t)
+	rc, h, cancel := bi.Unwrap()
+	defer cancel()
+
+	bi.Authenticate()
+
+	game := bi.MakeHTMLGame("Ledger", func(ac *mitch.ArchiveContext) {
+		ac.Entry("index.html").String("<p>Remember me</p>")
+	})
+
+	queueRes, err := messages.InstallQueue.TestCall(rc, butlerd.InstallQueueParams{
+		Game:              game,
+		InstallLocationID: "tmp",
+		QueueDownload:     true,
+	})
+	must(err)
+
+	historyRes, err := messages.DownloadsHistory.TestCall(rc, butlerd.DownloadsHistoryParams{})
+	must(err)
+	assert.Empty(historyRes.Items)
+
+	messages.DownloadsDriveFinished.Register(h, func(params butlerd.DownloadsDriveFinishedNotification) {
+		_, err := messages.DownloadsDriveCancel.TestCall(rc, butlerd.DownloadsDriveCancelParams{})
+		must(err)
+	})
+
+	driveDone := make(chan error, 1)
+	go func() {
+		_, err := messages.DownloadsDrive.TestCall(rc, butlerd.DownloadsDriveParams{})
+		driveDone <- err
+	}()
+
+	select {
+	case err := <-driveDone:
+		must(err)
+	case <-time.After(10 * time.Second):
+		must(errors.New("timed out"))
+	}
+
+	historyRes, err = messages.DownloadsHistory.TestCall(rc, butlerd.DownloadsHistoryParams{})
+	must(err)
+	assert.Len(historyRes.Items, 1)
+	entry := historyRes.Items[0]
+	assert.EqualValues(queueRes.ID, entry.DownloadID)
+	assert.EqualValues(queueRes.CaveID, entry.CaveID)
+	assert.EqualValues(game.ID, entry.Game.ID)
+	assert.EqualValues("install", entry.Strategy)
+	assert.Nil(entry.ErrorCode)
+	assert.True(entry.WallTime > 0)
+	assert.False(entry.FinishedAt.Before(entry.StartedAt))
+
+	historyRes, err = messages.DownloadsHistory.TestCall(rc, butlerd.DownloadsHistoryParams{
+		CaveID: "some-other-cave",
+	})
+	must(err)
+	assert.Empty(historyRes.Items)
+
+	historyRes, err = messages.DownloadsHistory.TestCall(rc, butlerd.DownloadsHistoryParams{
+		Limit: 1,
+	})
+	must(err)
+	assert.Len(historyRes.Items, 1)
+	assert.EqualValues("", historyRes.NextCursor)
+}
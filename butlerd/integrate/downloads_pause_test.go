@@ -0,0 +1,86 @@
+package integrate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/messages"
+	"github.com/itchio/mitch"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DownloadsPause(t *testing.T) {
+	assert := assert.New(t)
+
+	bi := newInstance(t)
+	rc, h, cancel := bi.Unwrap()
+	defer cancel()
+
+	bi.Authenticate()
+
+	game := bi.MakeHTMLGame("Press Start", func(ac *mitch.ArchiveContext) {
+		ac.Entry("index.html").String("<p>Paused</p>")
+	})
+
+	queueRes, err := messages.InstallQueue.TestCall(rc, butlerd.InstallQueueParams{
+		Game:              game,
+		InstallLocationID: "tmp",
+		QueueDownload:     true,
+	})
+	must(err)
+
+	_, err = messages.DownloadsPause.TestCall(rc, butlerd.DownloadsPauseParams{
+		DownloadID: queueRes.ID,
+	})
+	must(err)
+
+	listRes, err := messages.DownloadsList.TestCall(rc, butlerd.DownloadsListParams{})
+	must(err)
+	assert.Len(listRes.Downloads, 1)
+	assert.True(listRes.Downloads[0].Paused)
+
+	started := make(chan struct{}, 1)
+	messages.DownloadsDriveStarted.Register(h, func(params butlerd.DownloadsDriveStartedNotification) {
+		started <- struct{}{}
+	})
+	finished := make(chan struct{}, 1)
+	messages.DownloadsDriveFinished.Register(h, func(params butlerd.DownloadsDriveFinishedNotification) {
+		finished <- struct{}{}
+	})
+
+	driveDone := make(chan error, 1)
+	go func() {
+		_, err := messages.DownloadsDrive.TestCall(rc, butlerd.DownloadsDriveParams{})
+		driveDone <- err
+	}()
+
+	select {
+	case <-started:
+		must(errors.New("paused download should not be started"))
+	case <-time.After(2 * time.Second):
+		// good
+	}
+
+	_, err = messages.DownloadsResume.TestCall(rc, butlerd.DownloadsResumeParams{
+		DownloadID: queueRes.ID,
+	})
+	must(err)
+
+	select {
+	case <-finished:
+		// good
+	case <-time.After(10 * time.Second):
+		must(errors.New("timed out waiting for resumed download"))
+	}
+
+	_, err = messages.DownloadsDriveCancel.TestCall(rc, butlerd.DownloadsDriveCancelParams{})
+	must(err)
+	must(<-driveDone)
+
+	_, err = messages.DownloadsPause.TestCall(rc, butlerd.DownloadsPauseParams{
+		DownloadID: queueRes.ID,
+	})
+	assert.Error(err, "should not be able to pause finished download")
+}
@@ -0,0 +1,153 @@
+package integrate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/messages"
+	"github.com/itchio/mitch"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DownloadsRetryPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	bi := newInstance(t)
+	rc, h, cancel := bi.Unwrap()
+	defer cancel()
+
+	bi.Authenticate()
+
+	queue := func(title string) *butlerd.InstallQueueResult {
+		game := bi.MakeHTMLGame(title, func(ac *mitch.ArchiveContext) {
+			ac.Entry("index.html").String("<p>Again!</p>")
+		})
+
+		queueRes, err := messages.InstallQueue.TestCall(rc, butlerd.InstallQueueParams{
+			Game:              game,
+			InstallLocationID: "tmp",
+			QueueDownload:     true,
+		})
+		must(err)
+		return queueRes
+	}
+
+	getDownload := func(downloadID string) *butlerd.Download {
+		listRes, err := messages.DownloadsList.TestCall(rc, butlerd.DownloadsListParams{})
+		must(err)
+		for _, dl := range listRes.Downloads {
+			if dl.ID == downloadID {
+				return dl
+			}
+		}
+		must(errors.Errorf("download %s not found", downloadID))
+		return nil
+	}
+
+	const initialDelay = 30.0
+	retryPolicy := &butlerd.DownloadRetryPolicy{
+		MaxAttempts:  3,
+		InitialDelay: initialDelay,
+		MaxDelay:     60,
+	}
+
+	errored := make(chan *butlerd.Download, 1)
+	messages.DownloadsDriveErrored.Register(h, func(params butlerd.DownloadsDriveErroredNotification) {
+		errored <- params.Download
+		_, err := messages.DownloadsDriveCancel.TestCall(rc, butlerd.DownloadsDriveCancelParams{})
+		must(err)
+	})
+
+	finished := make(chan *butlerd.Download, 1)
+	messages.DownloadsDriveFinished.Register(h, func(params butlerd.DownloadsDriveFinishedNotification) {
+		finished <- params.Download
+		_, err := messages.DownloadsDriveCancel.TestCall(rc, butlerd.DownloadsDriveCancelParams{})
+		must(err)
+	})
+
+	drive := func() {
+		driveDone := make(chan error, 1)
+		go func() {
+			_, err := messages.DownloadsDrive.TestCall(rc, butlerd.DownloadsDriveParams{
+				RetryPolicy: retryPolicy,
+			})
+			driveDone <- err
+		}()
+
+		select {
+		case err := <-driveDone:
+			must(err)
+		case <-time.After(10 * time.Second):
+			must(errors.New("timed out"))
+		}
+	}
+
+	// network errors are transient: the first attempt fails while we're
+	// offline, and the download is retried once we're back.
+	flaky := queue("Try Again")
+
+	_, err := messages.NetworkSetSimulateOffline.TestCall(rc, butlerd.NetworkSetSimulateOfflineParams{
+		Enabled: true,
+	})
+	must(err)
+
+	var failedAttempt *butlerd.Download
+	var failedAt time.Time
+	messages.DownloadsDriveNetworkStatus.Register(h, func(params butlerd.DownloadsDriveNetworkStatusNotification) {
+		if params.Status != butlerd.NetworkStatusOffline || failedAttempt != nil {
+			return
+		}
+
+		failedAt = time.Now().UTC()
+		failedAttempt = getDownload(flaky.ID)
+		_, err := messages.NetworkSetSimulateOffline.TestCall(rc, butlerd.NetworkSetSimulateOfflineParams{
+			Enabled: false,
+		})
+		must(err)
+	})
+
+	drive()
+	select {
+	case dl := <-finished:
+		assert.EqualValues(flaky.ID, dl.ID)
+		assert.EqualValues(1, dl.Attempts)
+		assert.Nil(dl.ErrorCode)
+	case dl := <-errored:
+		must(errors.Errorf("download should have been retried, but errored: %#v", dl))
+	default:
+		must(errors.New("download should have finished"))
+	}
+
+	if assert.NotNil(failedAttempt, "first attempt should have failed") {
+		assert.EqualValues(1, failedAttempt.Attempts)
+		assert.Nil(failedAttempt.Error, "failed attempt isn't an error yet")
+		if assert.NotNil(failedAttempt.ErrorCode) {
+			assert.EqualValues(butlerd.CodeNetworkDisconnected, *failedAttempt.ErrorCode)
+		}
+		if assert.NotNil(failedAttempt.NextAttemptAt) {
+			// the retry was scheduled with the initial delay, and only
+			// brought forward because the network came back
+			delay := failedAttempt.NextAttemptAt.Sub(failedAt).Seconds()
+			assert.InDelta(initialDelay, delay, 5)
+		}
+	}
+
+	// errors that are here to stay make downloads fail right away,
+	// whatever the retry policy says.
+	doomed := queue("Never Again")
+	store := bi.Server.Store()
+	delete(store.Uploads, doomed.Upload.ID)
+
+	drive()
+	select {
+	case dl := <-errored:
+		assert.EqualValues(doomed.ID, dl.ID)
+		assert.EqualValues(0, dl.Attempts)
+		assert.Nil(dl.NextAttemptAt)
+		assert.NotNil(dl.ErrorCode)
+	default:
+		must(errors.New("download should have errored"))
+	}
+}
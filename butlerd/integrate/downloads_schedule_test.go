@@ -0,0 +1,49 @@
+package integrate
+
+import (
+	"testing"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/messages"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DownloadsSchedule(t *testing.T) {
+	assert := assert.New(t)
+
+	bi := newInstance(t)
+	rc, _, cancel := bi.Unwrap()
+	defer cancel()
+
+	getRes, err := messages.DownloadsGetSchedule.TestCall(rc, butlerd.DownloadsGetScheduleParams{})
+	must(err)
+	assert.Empty(getRes.Schedule.Windows)
+	assert.EqualValues(0, getRes.Schedule.DefaultMaxRate)
+
+	_, err = messages.DownloadsSetSchedule.TestCall(rc, butlerd.DownloadsSetScheduleParams{
+		Schedule: &butlerd.DownloadSchedule{
+			Windows: []*butlerd.DownloadWindow{
+				{Start: "25:00", End: "06:00"},
+			},
+		},
+	})
+	assert.Error(err, "should reject invalid times of day")
+
+	schedule := &butlerd.DownloadSchedule{
+		Windows: []*butlerd.DownloadWindow{
+			{Start: "23:30", End: "06:00"},
+			{Start: "12:00", End: "13:00", MaxRate: 4096},
+		},
+		DefaultMaxRate:       1024,
+		UpdateMaxRate:        256,
+		UpdatesOnlyInWindows: true,
+	}
+	_, err = messages.DownloadsSetSchedule.TestCall(rc, butlerd.DownloadsSetScheduleParams{
+		Schedule: schedule,
+	})
+	must(err)
+
+	getRes, err = messages.DownloadsGetSchedule.TestCall(rc, butlerd.DownloadsGetScheduleParams{})
+	must(err)
+	assert.EqualValues(schedule, getRes.Schedule)
+}
@@ -0,0 +1,153 @@
+package integrate
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/messages"
+	"github.com/itchio/mitch"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Events(t *testing.T) {
+	assert := assert.New(t)
+
+	bi := newInstance(t)
+	rc, h, cancel := bi.Unwrap()
+	defer cancel()
+
+	bi.Authenticate()
+
+	store := bi.Server.Store()
+	_developer := store.MakeUser("Roll Fizzlebeef")
+	_game := _developer.MakeGame("Event Horizon Simulator")
+	_game.Type = "html"
+	_game.Publish()
+	_upload := _game.MakeUpload("All platforms")
+	_upload.SetAllPlatforms()
+	_upload.SetZipContentsCustom(func(ac *mitch.ArchiveContext) {
+		ac.Entry("index.html").String("<p>Hi!</p>")
+	})
+
+	game := bi.FetchGame(_game.ID)
+
+	// a second connection, watching what the first one does
+	watcherRc, watcherH, _ := bi.Connect()
+
+	var events []butlerd.EventsEventNotification
+	var eventsLock sync.Mutex
+	messages.EventsEvent.Register(watcherH, func(params butlerd.EventsEventNotification) {
+		eventsLock.Lock()
+		events = append(events, params)
+		eventsLock.Unlock()
+	})
+
+	subRes, err := messages.EventsSubscribe.TestCall(watcherRc, butlerd.EventsSubscribeParams{
+		Methods: []string{"TaskStarted"},
+	})
+	must(err)
+
+	otherSubRes, err := messages.EventsSubscribe.TestCall(watcherRc, butlerd.EventsSubscribeParams{
+		CaveIDs: []string{"some-other-cave"},
+	})
+	must(err)
+
+	_, err = messages.EventsSubscribe.TestCall(watcherRc, butlerd.EventsSubscribeParams{
+		Methods: []string{"Log"},
+	})
+	assert.Error(err, "should not be able to subscribe to arbitrary notifications")
+
+	queueRes, err := messages.InstallQueue.TestCall(rc, butlerd.InstallQueueParams{
+		Game:              game,
+		InstallLocationID: "tmp",
+	})
+	must(err)
+
+	_, err = messages.InstallPerform.TestCall(rc, butlerd.InstallPerformParams{
+		ID:            queueRes.ID,
+		StagingFolder: queueRes.StagingFolder,
+	})
+	must(err)
+
+	getEvents := func() []butlerd.EventsEventNotification {
+		eventsLock.Lock()
+		defer eventsLock.Unlock()
+		return append([]butlerd.EventsEventNotification(nil), events...)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(getEvents()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	received := getEvents()
+	assert.NotEmpty(received)
+	for _, ev := range received {
+		assert.EqualValues(subRes.SubscriptionID, ev.SubscriptionID)
+		assert.NotEqual(otherSubRes.SubscriptionID, ev.SubscriptionID)
+		assert.EqualValues("TaskStarted", ev.Method)
+		assert.EqualValues(queueRes.CaveID, ev.CaveID)
+	}
+
+	unsubRes, err := messages.EventsUnsubscribe.TestCall(watcherRc, butlerd.EventsUnsubscribeParams{
+		SubscriptionID: subRes.SubscriptionID,
+	})
+	must(err)
+	assert.True(unsubRes.DidUnsubscribe)
+
+	// Downloads.Drive intercepts the notifications of the installs it
+	// drives, subscribers must still get them
+	game = bi.MakeHTMLGame("Event Horizon Simulator 2", func(ac *mitch.ArchiveContext) {
+		ac.Entry("index.html").String("<p>Hi again!</p>")
+	})
+	queueRes, err = messages.InstallQueue.TestCall(rc, butlerd.InstallQueueParams{
+		Game:              game,
+		InstallLocationID: "tmp",
+		QueueDownload:     true,
+	})
+	must(err)
+
+	eventsLock.Lock()
+	events = nil
+	eventsLock.Unlock()
+
+	driveSubRes, err := messages.EventsSubscribe.TestCall(watcherRc, butlerd.EventsSubscribeParams{
+		Methods:     []string{"TaskStarted"},
+		DownloadIDs: []string{queueRes.ID},
+	})
+	must(err)
+
+	messages.DownloadsDriveFinished.Register(h, func(params butlerd.DownloadsDriveFinishedNotification) {
+		_, err := messages.DownloadsDriveCancel.TestCall(rc, butlerd.DownloadsDriveCancelParams{})
+		must(err)
+	})
+
+	driveDone := make(chan error, 1)
+	go func() {
+		_, err := messages.DownloadsDrive.TestCall(rc, butlerd.DownloadsDriveParams{})
+		driveDone <- err
+	}()
+
+	select {
+	case err := <-driveDone:
+		must(err)
+	case <-time.After(10 * time.Second):
+		must(errors.New("timed out"))
+	}
+
+	deadline = time.Now().Add(2 * time.Second)
+	for len(getEvents()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	received = getEvents()
+	assert.NotEmpty(received, "notifications intercepted by Downloads.Drive are published")
+	for _, ev := range received {
+		assert.EqualValues(driveSubRes.SubscriptionID, ev.SubscriptionID)
+		assert.EqualValues("TaskStarted", ev.Method)
+		assert.EqualValues(queueRes.ID, ev.DownloadID)
+	}
+}
@@ -0,0 +1,207 @@
+// +build !windows
+
+package integrate
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/messages"
+	"github.com/itchio/butler/manager"
+	itchio "github.com/itchio/go-itchio"
+	"github.com/itchio/mitch"
+	"github.com/itchio/ox"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CustomHosts(t *testing.T) {
+	assert := assert.New(t)
+
+	bi := newInstance(t)
+	rc, _, cancel := bi.Unwrap()
+	defer cancel()
+
+	bi.Authenticate()
+
+	store := bi.Server.Store()
+	_developer := store.MakeUser("Windows Enjoyer")
+	_game := _developer.MakeGame("Windows Exclusive")
+	_game.Publish()
+	_upload := _game.MakeUpload("Windows")
+	_upload.PlatformWindows = true
+	_upload.SetZipContents()
+
+	fetchCompatible := func() []*itchio.Upload {
+		res, err := messages.FetchGameUploads.TestCall(rc, butlerd.FetchGameUploadsParams{
+			GameID:         _game.ID,
+			OnlyCompatible: true,
+			Fresh:          true,
+		})
+		must(err)
+		return res.Uploads
+	}
+
+	if _, err := exec.LookPath("wine"); err != nil {
+		assert.Len(fetchCompatible(), 0, "windows upload isn't compatible without wine")
+	}
+
+	addRes, err := messages.HostsAdd.TestCall(rc, butlerd.HostsAddParams{
+		Name: "Fake Proton",
+		Host: manager.Host{
+			Runtime: ox.Runtime{Platform: ox.PlatformWindows, Is64: true},
+			Wrapper: &manager.Wrapper{
+				WrapperBinary: "sh",
+				Env: map[string]string{
+					"STEAM_COMPAT_DATA_PATH": "/tmp/proton-prefix",
+				},
+			},
+		},
+	})
+	must(err)
+	assert.NotEmpty(addRes.Host.ID)
+	assert.EqualValues("Fake Proton", addRes.Host.Name)
+
+	_, err = messages.HostsAdd.TestCall(rc, butlerd.HostsAddParams{
+		Host: manager.Host{
+			Runtime: ox.Runtime{Platform: ox.PlatformWindows, Is64: true},
+		},
+	})
+	assert.Error(err, "custom hosts must have a wrapper")
+
+	listRes, err := messages.HostsList.TestCall(rc, butlerd.HostsListParams{})
+	must(err)
+	if assert.Len(listRes.Hosts, 1) {
+		host := listRes.Hosts[0].Host
+		assert.EqualValues(addRes.Host.ID, listRes.Hosts[0].ID)
+		assert.EqualValues(ox.PlatformWindows, host.Runtime.Platform)
+		assert.EqualValues("sh", host.Wrapper.WrapperBinary)
+		assert.EqualValues("/tmp/proton-prefix", host.Wrapper.Env["STEAM_COMPAT_DATA_PATH"])
+	}
+
+	assert.Len(fetchCompatible(), 1, "windows upload is compatible with custom windows host")
+
+	_, err = messages.HostsRemove.TestCall(rc, butlerd.HostsRemoveParams{
+		ID: addRes.Host.ID,
+	})
+	must(err)
+
+	listRes, err = messages.HostsList.TestCall(rc, butlerd.HostsListParams{})
+	must(err)
+	assert.Len(listRes.Hosts, 0)
+
+	_, err = messages.HostsRemove.TestCall(rc, butlerd.HostsRemoveParams{
+		ID: addRes.Host.ID,
+	})
+	assert.Error(err, "can't remove a custom host twice")
+}
+
+func Test_CustomHostLaunch(t *testing.T) {
+	assert := assert.New(t)
+
+	bi := newInstance(t)
+	rc, _, cancel := bi.Unwrap()
+	defer cancel()
+
+	bi.Authenticate()
+
+	wrapperDir, err := ioutil.TempDir("", "custom-host")
+	must(err)
+	defer os.RemoveAll(wrapperDir)
+
+	wrapperPath := filepath.Join(wrapperDir, "fake-proton")
+	wrapperScript := "#!/bin/sh\necho \"wrapped $(basename \"$1\")\"\necho \"prefix=$STEAM_COMPAT_DATA_PATH\"\n"
+	must(ioutil.WriteFile(wrapperPath, []byte(wrapperScript), 0o755))
+
+	_, err = messages.HostsAdd.TestCall(rc, butlerd.HostsAddParams{
+		Name: "Fake Proton",
+		Host: manager.Host{
+			Runtime: ox.Runtime{Platform: ox.PlatformWindows, Is64: true},
+			Wrapper: &manager.Wrapper{
+				WrapperBinary: wrapperPath,
+				Env: map[string]string{
+					"STEAM_COMPAT_DATA_PATH": "/tmp/proton-prefix",
+				},
+			},
+		},
+	})
+	must(err)
+
+	store := bi.Server.Store()
+	_developer := store.MakeUser("Windows Enjoyer")
+	_game := _developer.MakeGame("Windows Exclusive")
+	_game.Publish()
+	_upload := _game.MakeUpload("Windows")
+	_upload.PlatformWindows = true
+	_upload.SetZipContentsCustom(func(ac *mitch.ArchiveContext) {
+		ac.Entry(".itch.toml").String(`
+[[actions]]
+name = "play"
+path = "game.exe"
+platform = "windows"
+		`)
+		_, err := ac.Entry("game.exe").Write(makeFakePE())
+		must(err)
+	})
+
+	queueRes, err := messages.InstallQueue.TestCall(rc, butlerd.InstallQueueParams{
+		Game:              bi.FetchGame(_game.ID),
+		InstallLocationID: "tmp",
+	})
+	must(err)
+
+	_, err = messages.InstallPerform.TestCall(rc, butlerd.InstallPerformParams{
+		ID:            queueRes.ID,
+		StagingFolder: queueRes.StagingFolder,
+	})
+	must(err)
+
+	_, err = messages.Launch.TestCall(rc, butlerd.LaunchParams{
+		CaveID:     queueRes.CaveID,
+		PrereqsDir: "/tmp/prereqs",
+	})
+	must(err)
+
+	listRes, err := messages.LaunchSessionsList.TestCall(rc, butlerd.LaunchSessionsListParams{
+		CaveID: queueRes.CaveID,
+		Limit:  1,
+	})
+	must(err)
+	if assert.Len(listRes.Items, 1) {
+		session := listRes.Items[0]
+		assert.EqualValues(wrapperPath, session.Wrapper)
+		assert.EqualValues([]string{
+			"wrapped game.exe",
+			"prefix=/tmp/proton-prefix",
+		}, session.Stdout)
+	}
+}
+
+// makeFakePE returns just enough of a 64-bit console PE executable
+// for it to be recognized as one. It doesn't run, but it doesn't
+// have to, the wrapper does.
+func makeFakePE() []byte {
+	const peOffset = 0x40
+	const optionalHeaderSize = 0xf0
+
+	buf := make([]byte, peOffset+4+20+optionalHeaderSize)
+	copy(buf, "MZ")
+	// relocation table past the DOS header, or it's taken for a DOS executable
+	binary.LittleEndian.PutUint16(buf[0x18:], 0x40)
+	binary.LittleEndian.PutUint32(buf[0x3c:], peOffset)
+
+	coff := buf[peOffset:]
+	copy(coff, "PE\x00\x00")
+	binary.LittleEndian.PutUint16(coff[4:], 0x8664) // machine: amd64
+	binary.LittleEndian.PutUint16(coff[20:], optionalHeaderSize)
+	binary.LittleEndian.PutUint16(coff[22:], 0x0022) // executable, large address aware
+
+	optional := coff[24:]
+	binary.LittleEndian.PutUint16(optional[0:], 0x020b) // PE32+
+	binary.LittleEndian.PutUint16(optional[68:], 3)     // console subsystem
+	return buf
+}
@@ -0,0 +1,121 @@
+package integrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/messages"
+	itchio "github.com/itchio/go-itchio"
+	"github.com/itchio/hush"
+	"github.com/itchio/mitch"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_InstallVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	bi := newInstance(t)
+	rc, h, cancel := bi.Unwrap()
+	defer cancel()
+
+	bi.Authenticate()
+
+	store := bi.Server.Store()
+	_developer := store.MakeUser("Careful Studio")
+	_game := _developer.MakeGame("Measure twice")
+	_game.Type = "html"
+	_game.Publish()
+	_upload := _game.MakeUpload("web version")
+	_upload.SetAllPlatforms()
+	constantSeed := int64(0x05adface)
+	_upload.PushBuild(func(ac *mitch.ArchiveContext) {
+		ac.SetName("html5.zip")
+		ac.Entry("index.html").String("<p>This is version 1</p>")
+		ac.Entry("data1.bin").Random(constantSeed, 256*1024)
+	})
+	_upload.PushBuild(func(ac *mitch.ArchiveContext) {
+		ac.SetName("html5.zip")
+		ac.Entry("index.html").String("<p>This is version 2</p>")
+		ac.Entry("data1.bin").Random(constantSeed, 256*1024)
+	})
+
+	ilRes, err := messages.InstallLocationsSetVerifyInstalls.TestCall(rc, butlerd.InstallLocationsSetVerifyInstallsParams{
+		ID:      "tmp",
+		Enabled: true,
+	})
+	must(err)
+	assert.True(ilRes.InstallLocation.VerifyInstalls)
+
+	var taskTypes []butlerd.TaskType
+	var verifyReason butlerd.TaskReason
+	messages.TaskStarted.Register(h, func(params butlerd.TaskStartedNotification) {
+		taskTypes = append(taskTypes, params.Type)
+		if params.Type == butlerd.TaskTypeVerify {
+			verifyReason = params.Reason
+		}
+	})
+	var wounds []butlerd.InstallWoundsNotification
+	messages.InstallWounds.Register(h, func(params butlerd.InstallWoundsNotification) {
+		wounds = append(wounds, params)
+	})
+
+	game := bi.FetchGame(_game.ID)
+	upload := bi.FetchUpload(_upload.ID)
+	buildsRes, err := bi.Client().ListUploadBuilds(rc.Ctx, itchio.ListUploadBuildsParams{
+		UploadID: upload.ID,
+	})
+	must(err)
+	// most recent first
+	olderBuild := buildsRes.Builds[1]
+	recentBuild := buildsRes.Builds[0]
+
+	queue1Res, err := messages.InstallQueue.TestCall(rc, butlerd.InstallQueueParams{
+		Game:              game,
+		InstallLocationID: "tmp",
+		Upload:            upload,
+		Build:             olderBuild,
+	})
+	must(err)
+	_, err = messages.InstallPerform.TestCall(rc, butlerd.InstallPerformParams{
+		ID:            queue1Res.ID,
+		StagingFolder: queue1Res.StagingFolder,
+	})
+	must(err)
+
+	assert.Contains(taskTypes, butlerd.TaskTypeVerify)
+	assert.EqualValues(butlerd.TaskReasonInstall, verifyReason)
+	assert.Empty(wounds, "fresh install should be healthy")
+
+	// data1.bin isn't touched by the patch, so this survives the upgrade
+	dataPath := filepath.Join(queue1Res.InstallFolder, "data1.bin")
+	f, err := os.OpenFile(dataPath, os.O_WRONLY, 0o644)
+	must(err)
+	_, err = f.Write([]byte("not what the signature says"))
+	must(err)
+	must(f.Close())
+
+	taskTypes = nil
+	queue2Res, err := messages.InstallQueue.TestCall(rc, butlerd.InstallQueueParams{
+		Game:   game,
+		CaveID: queue1Res.CaveID,
+		Upload: upload,
+		Build:  recentBuild,
+	})
+	must(err)
+	perform2Res, err := messages.InstallPerform.TestCall(rc, butlerd.InstallPerformParams{
+		ID:            queue2Res.ID,
+		StagingFolder: queue2Res.StagingFolder,
+	})
+	must(err)
+
+	assert.Contains(taskTypes, butlerd.TaskTypeVerify)
+	assert.EqualValues(butlerd.TaskReasonUpdate, verifyReason)
+	assert.Contains(taskTypes, butlerd.TaskTypeHeal)
+	if assert.Len(wounds, 1) {
+		assert.EqualValues([]string{"data1.bin"}, wounds[0].Paths)
+		assert.NotZero(wounds[0].TotalCorrupted)
+	}
+	assert.NotZero(bi.FindEvent(perform2Res.Events, hush.InstallEventHeal).Heal.TotalCorrupted)
+}
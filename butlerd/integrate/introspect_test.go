@@ -0,0 +1,30 @@
+package integrate
+
+import (
+	"testing"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/messages"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Introspect(t *testing.T) {
+	assert := assert.New(t)
+
+	rc, _, cancel := newInstance(t).Unwrap()
+	defer cancel()
+
+	res, err := messages.MetaIntrospect.TestCall(rc, butlerd.MetaIntrospectParams{})
+	must(err)
+
+	assert.Contains(res.Methods, "Version.Get")
+	assert.Contains(res.Methods, "Meta.Introspect")
+
+	var found bool
+	for _, req := range res.Spec.Requests {
+		if req.Method == "Version.Get" {
+			found = true
+		}
+	}
+	assert.True(found, "spec should contain Version.Get")
+}
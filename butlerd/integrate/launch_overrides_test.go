@@ -0,0 +1,148 @@
+// +build linux
+
+package integrate
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/messages"
+	"github.com/itchio/mitch"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LaunchOverrides(t *testing.T) {
+	assert := assert.New(t)
+
+	bi := newInstance(t)
+	rc, h, cancel := bi.Unwrap()
+	defer cancel()
+
+	bi.Authenticate()
+
+	store := bi.Server.Store()
+	_developer := store.MakeUser("Settings Tweaker")
+	_game := _developer.MakeGame("Windowed Mode Enthusiast")
+	_game.Publish()
+	_upload := _game.MakeUpload("Linux")
+	_upload.PlatformLinux = true
+	_upload.SetZipContentsCustom(func(ac *mitch.ArchiveContext) {
+		ac.Entry(".itch.toml").String(`
+[[actions]]
+name = "play"
+path = "play.sh"
+
+[[actions]]
+name = "editor"
+path = "editor.sh"
+		`)
+		ac.Entry("play.sh").String("#!/bin/sh\necho \"play $@\"\n")
+		ac.Entry("editor.sh").String("#!/bin/sh\necho \"editor $@\"\necho \"HUD=$HUD\"\npwd\n")
+		ac.Entry("data/readme.txt").String("Game data goes here")
+	})
+
+	numPicks := 0
+	messages.PickManifestAction.TestRegister(h, func(rc *butlerd.RequestContext, params butlerd.PickManifestActionParams) (*butlerd.PickManifestActionResult, error) {
+		numPicks++
+		return &butlerd.PickManifestActionResult{
+			Index: 1,
+		}, nil
+	})
+
+	game := bi.FetchGame(_game.ID)
+
+	queueRes, err := messages.InstallQueue.TestCall(rc, butlerd.InstallQueueParams{
+		Game:              game,
+		InstallLocationID: "tmp",
+	})
+	must(err)
+
+	_, err = messages.InstallPerform.TestCall(rc, butlerd.InstallPerformParams{
+		ID:            queueRes.ID,
+		StagingFolder: queueRes.StagingFolder,
+	})
+	must(err)
+
+	_, err = messages.CavesSetLaunchOverrides.TestCall(rc, butlerd.CavesSetLaunchOverridesParams{
+		CaveID: queueRes.CaveID,
+		Overrides: &butlerd.LaunchOverrides{
+			Args:             []string{"-windowed"},
+			Env:              map[string]string{"HUD": "1"},
+			WorkingDirectory: "data",
+			PreferredWrapper: "native",
+			RememberAction:   true,
+		},
+	})
+	must(err)
+
+	launch := func() []string {
+		_, err := messages.Launch.TestCall(rc, butlerd.LaunchParams{
+			CaveID:     queueRes.CaveID,
+			PrereqsDir: "/tmp/prereqs",
+		})
+		must(err)
+
+		listRes, err := messages.LaunchSessionsList.TestCall(rc, butlerd.LaunchSessionsListParams{
+			CaveID: queueRes.CaveID,
+			Limit:  1,
+		})
+		must(err)
+		return listRes.Items[0].Stdout
+	}
+
+	expectedStdout := []string{
+		"editor -windowed",
+		"HUD=1",
+		filepath.Join(queueRes.InstallFolder, "data"),
+	}
+
+	assert.EqualValues(expectedStdout, launch())
+	assert.EqualValues(1, numPicks)
+
+	assert.EqualValues(expectedStdout, launch())
+	assert.EqualValues(1, numPicks, "picked action is remembered")
+
+	caveRes, err := messages.FetchCave.TestCall(rc, butlerd.FetchCaveParams{
+		CaveID: queueRes.CaveID,
+	})
+	must(err)
+	remembered := caveRes.Cave.LaunchOverrides
+	if assert.NotNil(remembered) && assert.NotNil(remembered.ActionIndex) {
+		assert.EqualValues(1, *remembered.ActionIndex)
+		assert.EqualValues("editor", remembered.ActionName)
+		assert.EqualValues("64-bit Linux (native)", remembered.ActionHost)
+	}
+
+	// the targets changed since the action was remembered, ask again
+	staleIndex := int64(1)
+	remembered.ActionIndex = &staleIndex
+	remembered.ActionName = "level-editor"
+	_, err = messages.CavesSetLaunchOverrides.TestCall(rc, butlerd.CavesSetLaunchOverridesParams{
+		CaveID:    queueRes.CaveID,
+		Overrides: remembered,
+	})
+	must(err)
+
+	assert.EqualValues(expectedStdout, launch())
+	assert.EqualValues(2, numPicks, "stale remembered action isn't used")
+
+	caveRes, err = messages.FetchCave.TestCall(rc, butlerd.FetchCaveParams{
+		CaveID: queueRes.CaveID,
+	})
+	must(err)
+	if assert.NotNil(caveRes.Cave.LaunchOverrides) {
+		assert.EqualValues("editor", caveRes.Cave.LaunchOverrides.ActionName)
+	}
+
+	_, err = messages.CavesSetLaunchOverrides.TestCall(rc, butlerd.CavesSetLaunchOverridesParams{
+		CaveID: queueRes.CaveID,
+	})
+	must(err)
+
+	caveRes, err = messages.FetchCave.TestCall(rc, butlerd.FetchCaveParams{
+		CaveID: queueRes.CaveID,
+	})
+	must(err)
+	assert.Nil(caveRes.Cave.LaunchOverrides)
+}
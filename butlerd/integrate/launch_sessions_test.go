@@ -0,0 +1,77 @@
+// +build linux
+
+package integrate
+
+import (
+	"testing"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/messages"
+	"github.com/itchio/mitch"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LaunchSessions(t *testing.T) {
+	assert := assert.New(t)
+
+	bi := newInstance(t)
+	rc, _, cancel := bi.Unwrap()
+	defer cancel()
+
+	bi.Authenticate()
+
+	store := bi.Server.Store()
+	_developer := store.MakeUser("Crashy Developer")
+	_game := _developer.MakeGame("Crash Simulator")
+	_game.Publish()
+	_upload := _game.MakeUpload("Linux")
+	_upload.PlatformLinux = true
+	_upload.SetZipContentsCustom(func(ac *mitch.ArchiveContext) {
+		ac.Entry("crash.sh").String("#!/bin/sh\necho 'starting up'\necho 'segmentation fault' >&2\nexit 3\n")
+	})
+
+	game := bi.FetchGame(_game.ID)
+
+	queueRes, err := messages.InstallQueue.TestCall(rc, butlerd.InstallQueueParams{
+		Game:              game,
+		InstallLocationID: "tmp",
+	})
+	must(err)
+
+	_, err = messages.InstallPerform.TestCall(rc, butlerd.InstallPerformParams{
+		ID:            queueRes.ID,
+		StagingFolder: queueRes.StagingFolder,
+	})
+	must(err)
+
+	_, err = messages.Launch.TestCall(rc, butlerd.LaunchParams{
+		CaveID:     queueRes.CaveID,
+		PrereqsDir: "/tmp/prereqs",
+	})
+	assert.Error(err, "game exits with non-zero code right away")
+
+	listRes, err := messages.LaunchSessionsList.TestCall(rc, butlerd.LaunchSessionsListParams{
+		CaveID: queueRes.CaveID,
+	})
+	must(err)
+	assert.Len(listRes.Items, 1)
+
+	session := listRes.Items[0]
+	assert.EqualValues(queueRes.CaveID, session.CaveID)
+	assert.EqualValues(_game.ID, session.Game.ID)
+	assert.EqualValues(butlerd.LaunchStrategyNative, session.Strategy)
+	assert.EqualValues("", session.Wrapper)
+	assert.NotNil(session.EndedAt)
+	if assert.NotNil(session.ExitCode) {
+		assert.EqualValues(3, *session.ExitCode)
+	}
+	assert.EqualValues([]string{"starting up"}, session.Stdout)
+	assert.EqualValues([]string{"segmentation fault"}, session.Stderr)
+	assert.NotNil(session.ErrorMessage)
+
+	otherRes, err := messages.LaunchSessionsList.TestCall(rc, butlerd.LaunchSessionsListParams{
+		GameID: _game.ID + 1,
+	})
+	must(err)
+	assert.Len(otherRes.Items, 0)
+}
@@ -0,0 +1,90 @@
+package integrate
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/messages"
+	itchio "github.com/itchio/go-itchio"
+	"github.com/itchio/mitch"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Metrics(t *testing.T) {
+	assert := assert.New(t)
+
+	// grab a free port for the metrics endpoint
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	must(err)
+	metricsAddress := l.Addr().String()
+	must(l.Close())
+
+	bi := newInstance(t, withMetricsAddress(metricsAddress))
+	rc, h, cancel := bi.Unwrap()
+	defer cancel()
+
+	bi.Authenticate()
+
+	store := bi.Server.Store()
+	_developer := store.MakeUser("Roll Fizzlebeef")
+	makeGame := func(title string) (*itchio.Game, *mitch.Upload) {
+		_game := _developer.MakeGame(title)
+		_game.Type = "html"
+		_game.Publish()
+		_upload := _game.MakeUpload("All platforms")
+		_upload.SetAllPlatforms()
+		_upload.SetZipContentsCustom(func(ac *mitch.ArchiveContext) {
+			ac.Entry("index.html").String("<p>Hi!</p>")
+			ac.Entry("data.bin").Random(0x4, 64*1024)
+		})
+		return bi.FetchGame(_game.ID), _upload
+	}
+
+	scrape := func() string {
+		res, err := http.Get("http://" + metricsAddress + "/metrics")
+		must(err)
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		must(err)
+		return string(body)
+	}
+
+	game, _ := makeGame("Advent Burger Simulator")
+	bi.Install(butlerd.InstallQueueParams{
+		Game: game,
+	})
+
+	text := scrape()
+	assert.Contains(text, `butlerd_installs_total{strategy="install",outcome="success"} 1`)
+	assert.NotContains(text, `strategy="none"`)
+	assert.Contains(text, `butlerd_requests_total{method="Install.Perform"`)
+	assert.NotContains(text, "\nbutlerd_downloads_drive_bytes_total ", "installs outside of Downloads.Drive aren't counted")
+
+	// bytes read by Downloads.Drive are counted
+	game, _upload := makeGame("Advent Burger Simulator 2")
+	_, err = messages.InstallQueue.TestCall(rc, butlerd.InstallQueueParams{
+		Game:              game,
+		InstallLocationID: "tmp",
+		QueueDownload:     true,
+	})
+	must(err)
+
+	messages.DownloadsDriveFinished.Register(h, func(params butlerd.DownloadsDriveFinishedNotification) {
+		_, err := messages.DownloadsDriveCancel.TestCall(rc, butlerd.DownloadsDriveCancelParams{})
+		must(err)
+	})
+	_, err = messages.DownloadsDrive.TestCall(rc, butlerd.DownloadsDriveParams{})
+	must(err)
+
+	matches := regexp.MustCompile(`\nbutlerd_downloads_drive_bytes_total (\S+)`).FindStringSubmatch(scrape())
+	if assert.Len(matches, 2) {
+		downloaded, err := strconv.ParseFloat(matches[1], 64)
+		must(err)
+		assert.True(downloaded >= float64(_upload.Size), "counted %v bytes, upload is %d", downloaded, _upload.Size)
+	}
+}
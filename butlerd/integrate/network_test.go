@@ -0,0 +1,38 @@
+package integrate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/messages"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NetworkGetStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	bi := newInstance(t)
+	rc, _, cancel := bi.Unwrap()
+	defer cancel()
+
+	res, err := messages.NetworkGetStatus.TestCall(rc, butlerd.NetworkGetStatusParams{})
+	must(err)
+	assert.EqualValues(butlerd.NetworkStatusOnline, res.Status)
+	assert.True(strings.HasSuffix(res.ProbeURL, bi.Server.Address().String()), "should probe the mock server")
+	assert.False(res.SimulateOffline)
+
+	_, err = messages.NetworkSetSimulateOffline.TestCall(rc, butlerd.NetworkSetSimulateOfflineParams{
+		Enabled: true,
+	})
+	must(err)
+
+	res, err = messages.NetworkGetStatus.TestCall(rc, butlerd.NetworkGetStatusParams{})
+	must(err)
+	assert.True(res.SimulateOffline)
+
+	_, err = messages.NetworkSetSimulateOffline.TestCall(rc, butlerd.NetworkSetSimulateOfflineParams{
+		Enabled: false,
+	})
+	must(err)
+}
@@ -0,0 +1,26 @@
+package integrate
+
+import (
+	"testing"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/messages"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Tasks(t *testing.T) {
+	assert := assert.New(t)
+
+	rc, _, cancel := newInstance(t).Unwrap()
+	defer cancel()
+
+	listRes, err := messages.TasksList.TestCall(rc, butlerd.TasksListParams{})
+	must(err)
+	assert.Empty(listRes.Tasks)
+
+	cancelRes, err := messages.TasksCancel.TestCall(rc, butlerd.TasksCancelParams{
+		TaskID: "not-a-task",
+	})
+	must(err)
+	assert.False(cancelRes.DidCancel)
+}
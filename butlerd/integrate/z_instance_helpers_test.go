@@ -11,6 +11,7 @@ import (
 	"github.com/itchio/butler/butlerd/messages"
 	itchio "github.com/itchio/go-itchio"
 	"github.com/itchio/hush"
+	"github.com/itchio/mitch"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -69,6 +70,24 @@ func (bi *ButlerInstance) FetchGame(gameID int64) *itchio.Game {
 	return gameRes.Game
 }
 
+// MakeHTMLGame publishes an HTML game with a single upload, whose only
+// build is html5.zip with the given entries, and fetches it from butlerd.
+func (bi *ButlerInstance) MakeHTMLGame(title string, entries func(ac *mitch.ArchiveContext)) *itchio.Game {
+	store := bi.Server.Store()
+	_developer := store.MakeUser("HTML Game Studio")
+	_game := _developer.MakeGame(title)
+	_game.Type = "html"
+	_game.Publish()
+	_upload := _game.MakeUpload("web version")
+	_upload.SetAllPlatforms()
+	_upload.PushBuild(func(ac *mitch.ArchiveContext) {
+		ac.SetName("html5.zip")
+		entries(ac)
+	})
+
+	return bi.FetchGame(_game.ID)
+}
+
 func (bi *ButlerInstance) FetchUpload(uploadID int64) *itchio.Upload {
 	res, err := bi.Client().GetUpload(context.Background(), itchio.GetUploadParams{UploadID: uploadID})
 	must(err)
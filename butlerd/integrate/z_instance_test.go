@@ -44,10 +44,31 @@ type ButlerInstance struct {
 }
 
 type instanceOpts struct {
+	metricsAddress string
+	buildCache     string
+	buildMirror    string
 }
 
 type instanceOpt func(o *instanceOpts)
 
+func withMetricsAddress(address string) instanceOpt {
+	return func(o *instanceOpts) {
+		o.metricsAddress = address
+	}
+}
+
+func withBuildCache(dir string) instanceOpt {
+	return func(o *instanceOpts) {
+		o.buildCache = dir
+	}
+}
+
+func withBuildMirror(url string) instanceOpt {
+	return func(o *instanceOpts) {
+		o.buildMirror = url
+	}
+}
+
 func init() {
 	color.NoColor = false
 }
@@ -124,8 +145,19 @@ func newInstance(t *testing.T, options ...instanceOpt) *ButlerInstance {
 	{
 		addressString := fmt.Sprintf("http://%s", server.Address())
 		args = append(args, "--address", addressString)
+		// don't need the internet to find out we're back online
+		args = append(args, "--probe-url", addressString)
 		logf("Using mock server %s", addressString)
 	}
+	if opts.metricsAddress != "" {
+		args = append(args, "--metrics-address", opts.metricsAddress)
+	}
+	if opts.buildCache != "" {
+		args = append(args, "--build-cache", opts.buildCache)
+	}
+	if opts.buildMirror != "" {
+		args = append(args, "--build-mirror", opts.buildMirror)
+	}
 	bExec := exec.CommandContext(ctx, conf.ButlerPath, args...)
 
 	stdout, err := bExec.StdoutPipe()
@@ -1,6 +1,7 @@
 package jsonrpc2
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -137,25 +138,6 @@ func (c *connImpl) Context() context.Context {
 	return c.ctx
 }
 
-func (c *connImpl) reply(id ID, res json.RawMessage) error {
-	resText, err := EncodeJSON(res)
-	if err != nil {
-		return err
-	}
-
-	return c.send(Message{
-		ID:     &id,
-		Result: &resText,
-	})
-}
-
-func (c *connImpl) replyWithError(id ID, rpcErr Error) error {
-	return c.send(Message{
-		ID:    &id,
-		Error: &rpcErr,
-	})
-}
-
 func (c *connImpl) warn(f string, args ...interface{}) {
 	// TODO: allow subscribing to warnings
 	log.Printf("json-rpc2: %s", fmt.Sprintf(f, args...))
@@ -169,10 +151,29 @@ func (c *connImpl) send(msg Message) error {
 		return err
 	}
 
+	return c.write(msgText)
+}
+
+// sendBatch sends several messages as a single JSON array,
+// see https://www.jsonrpc.org/specification#batch
+func (c *connImpl) sendBatch(msgs []Message) error {
+	for i := range msgs {
+		msgs[i].JsonRPC = "2.0"
+	}
+
+	msgText, err := json.MarshalSafeCollections(msgs)
+	if err != nil {
+		return err
+	}
+
+	return c.write(msgText)
+}
+
+func (c *connImpl) write(msgText []byte) error {
 	c.writeMutex.Lock()
 	defer c.writeMutex.Unlock()
 
-	err = c.transport.Write(msgText)
+	err := c.transport.Write(msgText)
 	if err != nil {
 		return err
 	}
@@ -193,6 +194,11 @@ func (c *connImpl) receiveLoop() {
 			return
 		}
 
+		if isBatch(msgText) {
+			c.handleIncomingBatch(msgText)
+			continue
+		}
+
 		var msg Message
 		err = DecodeJSON(msgText, &msg)
 		if err != nil {
@@ -247,43 +253,147 @@ func (c *connImpl) handleIncomingMessage(msg Message) {
 				Params: msg.Params,
 			}
 			go func() {
-				res, reqErr := c.handler.HandleRequest(c, req)
-
-				if reqErr != nil {
-					var err error
-
-					if rpcErr, ok := reqErr.(*Error); ok {
-						err = c.replyWithError(id, *rpcErr)
-					} else {
-						rpcErr := Error{
-							Code:    CodeInternalError,
-							Message: "internal JSON-RPC 2.0 error",
-							Data:    nil,
-						}
-						err = c.replyWithError(id, rpcErr)
-					}
-					if err != nil {
-						c.warn("while replying with error: %+v", err)
-						return
-					}
-				} else {
-					resText, err := EncodeJSON(res)
-					if err != nil {
-						c.warn("while encoding result as JSON: %+v", err)
-						return
-					}
-
-					err = c.reply(id, resText)
-					if err != nil {
-						c.warn("while replying with result: %+v", err)
-						return
-					}
+				reply := c.handleRequest(req)
+				if reply == nil {
+					return
+				}
+
+				err := c.send(*reply)
+				if err != nil {
+					c.warn("while replying: %+v", err)
 				}
 			}()
 		}
 	}
 }
 
+// handleRequest passes a request to the handler and returns the reply
+// to send back, or nil if no reply can be sent.
+func (c *connImpl) handleRequest(req Request) *Message {
+	id := req.ID
+	res, reqErr := c.handler.HandleRequest(c, req)
+
+	if reqErr != nil {
+		rpcErr, ok := reqErr.(*Error)
+		if !ok {
+			rpcErr = &Error{
+				Code:    CodeInternalError,
+				Message: "internal JSON-RPC 2.0 error",
+				Data:    nil,
+			}
+		}
+		return &Message{
+			ID:    &id,
+			Error: rpcErr,
+		}
+	}
+
+	resText, err := EncodeJSON(res)
+	if err != nil {
+		c.warn("while encoding result as JSON: %+v", err)
+		return nil
+	}
+
+	return &Message{
+		ID:     &id,
+		Result: &resText,
+	}
+}
+
+func isBatch(msgText []byte) bool {
+	trimmed := bytes.TrimLeft(msgText, " \t\r\n")
+	return len(trimmed) > 0 && trimmed[0] == '['
+}
+
+// handleIncomingBatch dispatches all requests of a batch concurrently,
+// then sends their replies as a single array, in the order the requests
+// came in. Notifications and responses found in a batch are handled
+// as if they had been sent separately.
+func (c *connImpl) handleIncomingBatch(msgText []byte) {
+	var rawMsgs []json.RawMessage
+	err := DecodeJSON(msgText, &rawMsgs)
+	if err != nil {
+		c.warn("%+v, for input %q", err, string(msgText))
+		err := c.send(Message{
+			Error: &Error{
+				Code:    CodeParseError,
+				Message: "invalid batch",
+			},
+		})
+		if err != nil {
+			c.warn("while replying to invalid batch: %+v", err)
+		}
+		return
+	}
+
+	if len(rawMsgs) == 0 {
+		err := c.send(Message{
+			Error: &Error{
+				Code:    CodeInvalidRequest,
+				Message: "empty batch",
+			},
+		})
+		if err != nil {
+			c.warn("while replying to empty batch: %+v", err)
+		}
+		return
+	}
+
+	// don't block the receive loop: requests in the batch may
+	// need to make calls of their own before they can complete.
+	go func() {
+		replies := make([]*Message, len(rawMsgs))
+
+		var wg sync.WaitGroup
+		for i, rawMsg := range rawMsgs {
+			var msg Message
+			err := DecodeJSON(rawMsg, &msg)
+			if err != nil || msg.JsonRPC != "2.0" {
+				replies[i] = &Message{
+					Error: &Error{
+						Code:    CodeInvalidRequest,
+						Message: "invalid request in batch",
+					},
+				}
+				continue
+			}
+
+			if msg.Method == nil || msg.ID == nil {
+				// notifications and responses don't get a reply
+				c.handleIncomingMessage(msg)
+				continue
+			}
+
+			req := Request{
+				ID:     *msg.ID,
+				Method: *msg.Method,
+				Params: msg.Params,
+			}
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				replies[i] = c.handleRequest(req)
+			}(i)
+		}
+		wg.Wait()
+
+		var batch []Message
+		for _, reply := range replies {
+			if reply != nil {
+				batch = append(batch, *reply)
+			}
+		}
+		if len(batch) == 0 {
+			return
+		}
+
+		err := c.sendBatch(batch)
+		if err != nil {
+			c.warn("while replying to batch: %+v", err)
+		}
+	}()
+}
+
 func (c *connImpl) Notify(method string, params interface{}) error {
 	paramsText, err := EncodeJSON(params)
 	if err != nil {
@@ -374,6 +484,20 @@ type Message struct {
 	Error *Error `json:"error,omitempty"`
 }
 
+// MarshalJSON omits "id" for notifications, but error replies to requests
+// whose id couldn't be determined must have `"id": null`, see
+// https://www.jsonrpc.org/specification#response_object
+func (m Message) MarshalJSON() ([]byte, error) {
+	type message Message
+	if m.Error != nil && m.ID == nil {
+		return json.MarshalSafeCollections(struct {
+			message
+			ID *ID `json:"id"`
+		}{message: message(m)})
+	}
+	return json.MarshalSafeCollections(message(m))
+}
+
 // A JSON-RPC2 request, see https://www.jsonrpc.org/specification#request_object
 type Request struct {
 	ID     ID
@@ -0,0 +1,135 @@
+package jsonrpc2_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/helloeave/json"
+	"github.com/itchio/butler/butlerd/jsonrpc2"
+	"github.com/stretchr/testify/assert"
+)
+
+type doubleHandler struct{}
+
+func (h *doubleHandler) HandleRequest(conn jsonrpc2.Conn, req jsonrpc2.Request) (interface{}, error) {
+	if req.Method != "Double" {
+		return nil, &jsonrpc2.Error{
+			Code:    jsonrpc2.CodeMethodNotFound,
+			Message: "method not found",
+		}
+	}
+
+	var n int64
+	err := jsonrpc2.DecodeJSON(*req.Params, &n)
+	if err != nil {
+		return nil, err
+	}
+
+	// reply out of order, to make sure batch replies are re-ordered
+	time.Sleep(time.Duration(10-n) * time.Millisecond)
+	return n * 2, nil
+}
+
+func (h *doubleHandler) HandleNotification(conn jsonrpc2.Conn, notif jsonrpc2.Notification) {
+}
+
+func TestBatch(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	serverSide, clientSide := net.Pipe()
+	conn := jsonrpc2.NewConn(ctx, jsonrpc2.NewRwcTransport(serverSide), &doubleHandler{})
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(clientSide)
+	roundtrip := func(payload string) []jsonrpc2.Message {
+		t.Helper()
+
+		_, err := clientSide.Write([]byte(payload + "\n"))
+		assert.NoError(err)
+
+		assert.True(scanner.Scan())
+		var replies []jsonrpc2.Message
+		assert.NoError(json.Unmarshal(scanner.Bytes(), &replies))
+		return replies
+	}
+
+	// over this transport, each message needs to be on a single line
+	replies := roundtrip(`[` +
+		`{"jsonrpc": "2.0", "id": 1, "method": "Double", "params": 1},` +
+		`{"jsonrpc": "2.0", "method": "Double", "params": 2},` +
+		`{"jsonrpc": "2.0", "id": 3, "method": "Double", "params": 3},` +
+		`{"jsonrpc": "2.0", "id": 4, "method": "Triple", "params": 4},` +
+		`{"foo": "bar"}` +
+		`]`)
+	assert.Len(replies, 4)
+
+	assert.EqualValues(1, *replies[0].ID)
+	assert.EqualValues("2", string(*replies[0].Result))
+
+	assert.EqualValues(3, *replies[1].ID)
+	assert.EqualValues("6", string(*replies[1].Result))
+
+	assert.EqualValues(4, *replies[2].ID)
+	assert.EqualValues(jsonrpc2.CodeMethodNotFound, replies[2].Error.Code)
+
+	assert.Nil(replies[3].ID)
+	assert.EqualValues(jsonrpc2.CodeInvalidRequest, replies[3].Error.Code)
+
+	// error replies must have an id, even when it's null
+	badBatch := func(payload string) *jsonrpc2.Error {
+		t.Helper()
+
+		_, err := clientSide.Write([]byte(payload + "\n"))
+		assert.NoError(err)
+
+		assert.True(scanner.Scan())
+		var reply map[string]interface{}
+		assert.NoError(json.Unmarshal(scanner.Bytes(), &reply))
+		assert.Contains(reply, "id")
+		assert.Nil(reply["id"])
+
+		var msg jsonrpc2.Message
+		assert.NoError(json.Unmarshal(scanner.Bytes(), &msg))
+		if assert.NotNil(msg.Error) {
+			return msg.Error
+		}
+		return &jsonrpc2.Error{}
+	}
+
+	assert.EqualValues(jsonrpc2.CodeInvalidRequest, badBatch(`[]`).Code)
+	assert.EqualValues(jsonrpc2.CodeParseError, badBatch(`[{"jsonrpc": "2.0", "method"`).Code)
+	assert.EqualValues(jsonrpc2.CodeParseError, badBatch(`[1, 2`).Code)
+
+	// the connection is still usable afterwards
+	replies = roundtrip(`[{"jsonrpc": "2.0", "id": 5, "method": "Double", "params": 5}]`)
+	assert.Len(replies, 1)
+	assert.EqualValues("10", string(*replies[0].Result))
+}
+
+func TestMessageID(t *testing.T) {
+	assert := assert.New(t)
+
+	method := "Version.Get"
+	bs, err := json.Marshal(jsonrpc2.Message{
+		JsonRPC: "2.0",
+		Method:  &method,
+	})
+	assert.NoError(err)
+	assert.EqualValues(`{"jsonrpc":"2.0","method":"Version.Get"}`, string(bs))
+
+	bs, err = json.Marshal(jsonrpc2.Message{
+		JsonRPC: "2.0",
+		Error: &jsonrpc2.Error{
+			Code:    jsonrpc2.CodeParseError,
+			Message: "parse error",
+		},
+	})
+	assert.NoError(err)
+	assert.EqualValues(`{"jsonrpc":"2.0","error":{"code":-32700,"message":"parse error","data":null},"id":null}`, string(bs))
+}
@@ -20,10 +20,17 @@ type rwcTransport struct {
 	closeMutex sync.Mutex
 }
 
+// Some messages (like the result of `Meta.Introspect`) are way
+// over bufio's default token size.
+const maxMessageSize = 16 * 1024 * 1024
+
 func NewRwcTransport(rwc ReadWriteClose) Transport {
+	scanner := bufio.NewScanner(rwc)
+	scanner.Buffer(nil, maxMessageSize)
+
 	return &rwcTransport{
 		inner:     rwc,
-		scanner:   bufio.NewScanner(rwc),
+		scanner:   scanner,
 		closed:    false,
 		closeChan: make(chan struct{}),
 	}
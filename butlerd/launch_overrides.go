@@ -0,0 +1,37 @@
+package butlerd
+
+import (
+	"encoding/json"
+
+	"github.com/itchio/butler/database/models"
+	"github.com/pkg/errors"
+)
+
+// UnmarshalLaunchOverrides returns nil if the cave has no launch overrides
+func UnmarshalLaunchOverrides(in models.JSON) (*LaunchOverrides, error) {
+	if in == "" {
+		return nil, nil
+	}
+
+	var out LaunchOverrides
+	err := json.Unmarshal([]byte(in), &out)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshalling launch overrides")
+	}
+	return &out, nil
+}
+
+// MarshalLaunchOverrides clears out if in is nil
+func MarshalLaunchOverrides(in *LaunchOverrides, out *models.JSON) error {
+	if in == nil {
+		*out = ""
+		return nil
+	}
+
+	contents, err := json.Marshal(in)
+	if err != nil {
+		return errors.Wrap(err, "marshalling launch overrides")
+	}
+	*out = models.JSON(contents)
+	return nil
+}
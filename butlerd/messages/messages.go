@@ -140,6 +140,46 @@ func (r *MetaShutdownType) TestCall(rc *butlerd.RequestContext, params butlerd.M
 
 var MetaShutdown *MetaShutdownType
 
+// Meta.Introspect (Request)
+
+type MetaIntrospectType struct {}
+
+var _ RequestMessage = (*MetaIntrospectType)(nil)
+
+func (r *MetaIntrospectType) Method() string {
+  return "Meta.Introspect"
+}
+
+func (r *MetaIntrospectType) Register(router router, f func(*butlerd.RequestContext, butlerd.MetaIntrospectParams) (*butlerd.MetaIntrospectResult, error)) {
+  router.Register("Meta.Introspect", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.MetaIntrospectParams
+    err := json.Unmarshal(*rc.Params, &params)
+    if err != nil {
+    	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
+    }
+    err = params.Validate()
+    if err != nil {
+    	return nil, err
+    }
+    res, err := f(rc, params)
+    if err != nil {
+    	return nil, err
+    }
+    if res == nil {
+    	return nil, errors.New("internal error: nil result for Meta.Introspect")
+    }
+    return res, nil
+  })
+}
+
+func (r *MetaIntrospectType) TestCall(rc *butlerd.RequestContext, params butlerd.MetaIntrospectParams) (*butlerd.MetaIntrospectResult, error) {
+  var result butlerd.MetaIntrospectResult
+  err := rc.Call("Meta.Introspect", params, &result)
+  return &result, err
+}
+
+var MetaIntrospect *MetaIntrospectType
+
 // MetaFlowEstablished (Notification)
 
 type MetaFlowEstablishedType struct {}
@@ -289,6 +329,46 @@ func (r *NetworkSetBandwidthThrottleType) TestCall(rc *butlerd.RequestContext, p
 
 var NetworkSetBandwidthThrottle *NetworkSetBandwidthThrottleType
 
+// Network.GetStatus (Request)
+
+type NetworkGetStatusType struct {}
+
+var _ RequestMessage = (*NetworkGetStatusType)(nil)
+
+func (r *NetworkGetStatusType) Method() string {
+  return "Network.GetStatus"
+}
+
+func (r *NetworkGetStatusType) Register(router router, f func(*butlerd.RequestContext, butlerd.NetworkGetStatusParams) (*butlerd.NetworkGetStatusResult, error)) {
+  router.Register("Network.GetStatus", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.NetworkGetStatusParams
+    err := json.Unmarshal(*rc.Params, &params)
+    if err != nil {
+    	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
+    }
+    err = params.Validate()
+    if err != nil {
+    	return nil, err
+    }
+    res, err := f(rc, params)
+    if err != nil {
+    	return nil, err
+    }
+    if res == nil {
+    	return nil, errors.New("internal error: nil result for Network.GetStatus")
+    }
+    return res, nil
+  })
+}
+
+func (r *NetworkGetStatusType) TestCall(rc *butlerd.RequestContext, params butlerd.NetworkGetStatusParams) (*butlerd.NetworkGetStatusResult, error) {
+  var result butlerd.NetworkGetStatusResult
+  err := rc.Call("Network.GetStatus", params, &result)
+  return &result, err
+}
+
+var NetworkGetStatus *NetworkGetStatusType
+
 
 //==============================
 // Miscellaneous
@@ -1757,6 +1837,46 @@ func (r *CavesSetPinnedType) TestCall(rc *butlerd.RequestContext, params butlerd
 
 var CavesSetPinned *CavesSetPinnedType
 
+// Caves.SetLaunchOverrides (Request)
+
+type CavesSetLaunchOverridesType struct {}
+
+var _ RequestMessage = (*CavesSetLaunchOverridesType)(nil)
+
+func (r *CavesSetLaunchOverridesType) Method() string {
+  return "Caves.SetLaunchOverrides"
+}
+
+func (r *CavesSetLaunchOverridesType) Register(router router, f func(*butlerd.RequestContext, butlerd.CavesSetLaunchOverridesParams) (*butlerd.CavesSetLaunchOverridesResult, error)) {
+  router.Register("Caves.SetLaunchOverrides", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.CavesSetLaunchOverridesParams
+    err := json.Unmarshal(*rc.Params, &params)
+    if err != nil {
+    	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
+    }
+    err = params.Validate()
+    if err != nil {
+    	return nil, err
+    }
+    res, err := f(rc, params)
+    if err != nil {
+    	return nil, err
+    }
+    if res == nil {
+    	return nil, errors.New("internal error: nil result for Caves.SetLaunchOverrides")
+    }
+    return res, nil
+  })
+}
+
+func (r *CavesSetLaunchOverridesType) TestCall(rc *butlerd.RequestContext, params butlerd.CavesSetLaunchOverridesParams) (*butlerd.CavesSetLaunchOverridesResult, error) {
+  var result butlerd.CavesSetLaunchOverridesResult
+  err := rc.Call("Caves.SetLaunchOverrides", params, &result)
+  return &result, err
+}
+
+var CavesSetLaunchOverrides *CavesSetLaunchOverridesType
+
 // Install.CreateShortcut (Request)
 
 type InstallCreateShortcutType struct {}
@@ -2124,6 +2244,35 @@ func (r *TaskSucceededType) Register(router router, f func(butlerd.TaskSucceeded
 
 var TaskSucceeded *TaskSucceededType
 
+// Install.Wounds (Notification)
+
+type InstallWoundsType struct {}
+
+var _ NotificationMessage = (*InstallWoundsType)(nil)
+
+func (r *InstallWoundsType) Method() string {
+  return "Install.Wounds"
+}
+
+func (r *InstallWoundsType) Notify(rc *butlerd.RequestContext, params butlerd.InstallWoundsNotification) (error) {
+  return rc.Notify("Install.Wounds", params)
+}
+
+func (r *InstallWoundsType) Register(router router, f func(butlerd.InstallWoundsNotification)) {
+  router.RegisterNotification("Install.Wounds", func (notif jsonrpc2.Notification) {
+    var params butlerd.InstallWoundsNotification
+    if notif.Params != nil {
+      err := json.Unmarshal(*notif.Params, &params)
+      if err != nil {
+        return
+      }
+    }
+    f(params)
+  })
+}
+
+var InstallWounds *InstallWoundsType
+
 // Install.Locations.List (Request)
 
 type InstallLocationsListType struct {}
@@ -2204,6 +2353,46 @@ func (r *InstallLocationsAddType) TestCall(rc *butlerd.RequestContext, params bu
 
 var InstallLocationsAdd *InstallLocationsAddType
 
+// Install.Locations.SetVerifyInstalls (Request)
+
+type InstallLocationsSetVerifyInstallsType struct {}
+
+var _ RequestMessage = (*InstallLocationsSetVerifyInstallsType)(nil)
+
+func (r *InstallLocationsSetVerifyInstallsType) Method() string {
+  return "Install.Locations.SetVerifyInstalls"
+}
+
+func (r *InstallLocationsSetVerifyInstallsType) Register(router router, f func(*butlerd.RequestContext, butlerd.InstallLocationsSetVerifyInstallsParams) (*butlerd.InstallLocationsSetVerifyInstallsResult, error)) {
+  router.Register("Install.Locations.SetVerifyInstalls", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.InstallLocationsSetVerifyInstallsParams
+    err := json.Unmarshal(*rc.Params, &params)
+    if err != nil {
+    	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
+    }
+    err = params.Validate()
+    if err != nil {
+    	return nil, err
+    }
+    res, err := f(rc, params)
+    if err != nil {
+    	return nil, err
+    }
+    if res == nil {
+    	return nil, errors.New("internal error: nil result for Install.Locations.SetVerifyInstalls")
+    }
+    return res, nil
+  })
+}
+
+func (r *InstallLocationsSetVerifyInstallsType) TestCall(rc *butlerd.RequestContext, params butlerd.InstallLocationsSetVerifyInstallsParams) (*butlerd.InstallLocationsSetVerifyInstallsResult, error) {
+  var result butlerd.InstallLocationsSetVerifyInstallsResult
+  err := rc.Call("Install.Locations.SetVerifyInstalls", params, &result)
+  return &result, err
+}
+
+var InstallLocationsSetVerifyInstalls *InstallLocationsSetVerifyInstallsType
+
 // Install.Locations.Remove (Request)
 
 type InstallLocationsRemoveType struct {}
@@ -2718,24 +2907,19 @@ func (r *DownloadsDiscardType) TestCall(rc *butlerd.RequestContext, params butle
 
 var DownloadsDiscard *DownloadsDiscardType
 
+// Downloads.Pause (Request)
 
-//==============================
-// Update
-//==============================
-
-// CheckUpdate (Request)
-
-type CheckUpdateType struct {}
+type DownloadsPauseType struct {}
 
-var _ RequestMessage = (*CheckUpdateType)(nil)
+var _ RequestMessage = (*DownloadsPauseType)(nil)
 
-func (r *CheckUpdateType) Method() string {
-  return "CheckUpdate"
+func (r *DownloadsPauseType) Method() string {
+  return "Downloads.Pause"
 }
 
-func (r *CheckUpdateType) Register(router router, f func(*butlerd.RequestContext, butlerd.CheckUpdateParams) (*butlerd.CheckUpdateResult, error)) {
-  router.Register("CheckUpdate", func (rc *butlerd.RequestContext) (interface{}, error) {
-    var params butlerd.CheckUpdateParams
+func (r *DownloadsPauseType) Register(router router, f func(*butlerd.RequestContext, butlerd.DownloadsPauseParams) (*butlerd.DownloadsPauseResult, error)) {
+  router.Register("Downloads.Pause", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.DownloadsPauseParams
     err := json.Unmarshal(*rc.Params, &params)
     if err != nil {
     	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
@@ -2749,62 +2933,73 @@ func (r *CheckUpdateType) Register(router router, f func(*butlerd.RequestContext
     	return nil, err
     }
     if res == nil {
-    	return nil, errors.New("internal error: nil result for CheckUpdate")
+    	return nil, errors.New("internal error: nil result for Downloads.Pause")
     }
     return res, nil
   })
 }
 
-func (r *CheckUpdateType) TestCall(rc *butlerd.RequestContext, params butlerd.CheckUpdateParams) (*butlerd.CheckUpdateResult, error) {
-  var result butlerd.CheckUpdateResult
-  err := rc.Call("CheckUpdate", params, &result)
+func (r *DownloadsPauseType) TestCall(rc *butlerd.RequestContext, params butlerd.DownloadsPauseParams) (*butlerd.DownloadsPauseResult, error) {
+  var result butlerd.DownloadsPauseResult
+  err := rc.Call("Downloads.Pause", params, &result)
   return &result, err
 }
 
-var CheckUpdate *CheckUpdateType
-
-// GameUpdateAvailable (Notification)
+var DownloadsPause *DownloadsPauseType
 
-type GameUpdateAvailableType struct {}
+// Downloads.Resume (Request)
 
-var _ NotificationMessage = (*GameUpdateAvailableType)(nil)
+type DownloadsResumeType struct {}
 
-func (r *GameUpdateAvailableType) Method() string {
-  return "GameUpdateAvailable"
-}
+var _ RequestMessage = (*DownloadsResumeType)(nil)
 
-func (r *GameUpdateAvailableType) Notify(rc *butlerd.RequestContext, params butlerd.GameUpdateAvailableNotification) (error) {
-  return rc.Notify("GameUpdateAvailable", params)
+func (r *DownloadsResumeType) Method() string {
+  return "Downloads.Resume"
 }
 
-func (r *GameUpdateAvailableType) Register(router router, f func(butlerd.GameUpdateAvailableNotification)) {
-  router.RegisterNotification("GameUpdateAvailable", func (notif jsonrpc2.Notification) {
-    var params butlerd.GameUpdateAvailableNotification
-    if notif.Params != nil {
-      err := json.Unmarshal(*notif.Params, &params)
-      if err != nil {
-        return
-      }
+func (r *DownloadsResumeType) Register(router router, f func(*butlerd.RequestContext, butlerd.DownloadsResumeParams) (*butlerd.DownloadsResumeResult, error)) {
+  router.Register("Downloads.Resume", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.DownloadsResumeParams
+    err := json.Unmarshal(*rc.Params, &params)
+    if err != nil {
+    	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
     }
-    f(params)
+    err = params.Validate()
+    if err != nil {
+    	return nil, err
+    }
+    res, err := f(rc, params)
+    if err != nil {
+    	return nil, err
+    }
+    if res == nil {
+    	return nil, errors.New("internal error: nil result for Downloads.Resume")
+    }
+    return res, nil
   })
 }
 
-var GameUpdateAvailable *GameUpdateAvailableType
+func (r *DownloadsResumeType) TestCall(rc *butlerd.RequestContext, params butlerd.DownloadsResumeParams) (*butlerd.DownloadsResumeResult, error) {
+  var result butlerd.DownloadsResumeResult
+  err := rc.Call("Downloads.Resume", params, &result)
+  return &result, err
+}
 
-// SnoozeCave (Request)
+var DownloadsResume *DownloadsResumeType
 
-type SnoozeCaveType struct {}
+// Downloads.GetSchedule (Request)
 
-var _ RequestMessage = (*SnoozeCaveType)(nil)
+type DownloadsGetScheduleType struct {}
 
-func (r *SnoozeCaveType) Method() string {
-  return "SnoozeCave"
+var _ RequestMessage = (*DownloadsGetScheduleType)(nil)
+
+func (r *DownloadsGetScheduleType) Method() string {
+  return "Downloads.GetSchedule"
 }
 
-func (r *SnoozeCaveType) Register(router router, f func(*butlerd.RequestContext, butlerd.SnoozeCaveParams) (*butlerd.SnoozeCaveResult, error)) {
-  router.Register("SnoozeCave", func (rc *butlerd.RequestContext) (interface{}, error) {
-    var params butlerd.SnoozeCaveParams
+func (r *DownloadsGetScheduleType) Register(router router, f func(*butlerd.RequestContext, butlerd.DownloadsGetScheduleParams) (*butlerd.DownloadsGetScheduleResult, error)) {
+  router.Register("Downloads.GetSchedule", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.DownloadsGetScheduleParams
     err := json.Unmarshal(*rc.Params, &params)
     if err != nil {
     	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
@@ -2818,43 +3013,33 @@ func (r *SnoozeCaveType) Register(router router, f func(*butlerd.RequestContext,
     	return nil, err
     }
     if res == nil {
-    	return nil, errors.New("internal error: nil result for SnoozeCave")
+    	return nil, errors.New("internal error: nil result for Downloads.GetSchedule")
     }
     return res, nil
   })
 }
 
-func (r *SnoozeCaveType) TestCall(rc *butlerd.RequestContext, params butlerd.SnoozeCaveParams) (*butlerd.SnoozeCaveResult, error) {
-  var result butlerd.SnoozeCaveResult
-  err := rc.Call("SnoozeCave", params, &result)
+func (r *DownloadsGetScheduleType) TestCall(rc *butlerd.RequestContext, params butlerd.DownloadsGetScheduleParams) (*butlerd.DownloadsGetScheduleResult, error) {
+  var result butlerd.DownloadsGetScheduleResult
+  err := rc.Call("Downloads.GetSchedule", params, &result)
   return &result, err
 }
 
-var SnoozeCave *SnoozeCaveType
-
-
-//==============================
-// update
-//==============================
-
-
-//==============================
-// Launch
-//==============================
+var DownloadsGetSchedule *DownloadsGetScheduleType
 
-// Launch (Request)
+// Downloads.SetSchedule (Request)
 
-type LaunchType struct {}
+type DownloadsSetScheduleType struct {}
 
-var _ RequestMessage = (*LaunchType)(nil)
+var _ RequestMessage = (*DownloadsSetScheduleType)(nil)
 
-func (r *LaunchType) Method() string {
-  return "Launch"
+func (r *DownloadsSetScheduleType) Method() string {
+  return "Downloads.SetSchedule"
 }
 
-func (r *LaunchType) Register(router router, f func(*butlerd.RequestContext, butlerd.LaunchParams) (*butlerd.LaunchResult, error)) {
-  router.Register("Launch", func (rc *butlerd.RequestContext) (interface{}, error) {
-    var params butlerd.LaunchParams
+func (r *DownloadsSetScheduleType) Register(router router, f func(*butlerd.RequestContext, butlerd.DownloadsSetScheduleParams) (*butlerd.DownloadsSetScheduleResult, error)) {
+  router.Register("Downloads.SetSchedule", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.DownloadsSetScheduleParams
     err := json.Unmarshal(*rc.Params, &params)
     if err != nil {
     	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
@@ -2868,57 +3053,341 @@ func (r *LaunchType) Register(router router, f func(*butlerd.RequestContext, but
     	return nil, err
     }
     if res == nil {
-    	return nil, errors.New("internal error: nil result for Launch")
+    	return nil, errors.New("internal error: nil result for Downloads.SetSchedule")
     }
     return res, nil
   })
 }
 
-func (r *LaunchType) TestCall(rc *butlerd.RequestContext, params butlerd.LaunchParams) (*butlerd.LaunchResult, error) {
-  var result butlerd.LaunchResult
-  err := rc.Call("Launch", params, &result)
+func (r *DownloadsSetScheduleType) TestCall(rc *butlerd.RequestContext, params butlerd.DownloadsSetScheduleParams) (*butlerd.DownloadsSetScheduleResult, error) {
+  var result butlerd.DownloadsSetScheduleResult
+  err := rc.Call("Downloads.SetSchedule", params, &result)
   return &result, err
 }
 
-var Launch *LaunchType
-
-// LaunchRunning (Notification)
+var DownloadsSetSchedule *DownloadsSetScheduleType
 
-type LaunchRunningType struct {}
+// Downloads.History (Request)
 
-var _ NotificationMessage = (*LaunchRunningType)(nil)
+type DownloadsHistoryType struct {}
 
-func (r *LaunchRunningType) Method() string {
-  return "LaunchRunning"
-}
+var _ RequestMessage = (*DownloadsHistoryType)(nil)
 
-func (r *LaunchRunningType) Notify(rc *butlerd.RequestContext, params butlerd.LaunchRunningNotification) (error) {
-  return rc.Notify("LaunchRunning", params)
+func (r *DownloadsHistoryType) Method() string {
+  return "Downloads.History"
 }
 
-func (r *LaunchRunningType) Register(router router, f func(butlerd.LaunchRunningNotification)) {
-  router.RegisterNotification("LaunchRunning", func (notif jsonrpc2.Notification) {
-    var params butlerd.LaunchRunningNotification
-    if notif.Params != nil {
-      err := json.Unmarshal(*notif.Params, &params)
-      if err != nil {
-        return
-      }
+func (r *DownloadsHistoryType) Register(router router, f func(*butlerd.RequestContext, butlerd.DownloadsHistoryParams) (*butlerd.DownloadsHistoryResult, error)) {
+  router.Register("Downloads.History", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.DownloadsHistoryParams
+    err := json.Unmarshal(*rc.Params, &params)
+    if err != nil {
+    	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
     }
-    f(params)
+    err = params.Validate()
+    if err != nil {
+    	return nil, err
+    }
+    res, err := f(rc, params)
+    if err != nil {
+    	return nil, err
+    }
+    if res == nil {
+    	return nil, errors.New("internal error: nil result for Downloads.History")
+    }
+    return res, nil
   })
 }
 
-var LaunchRunning *LaunchRunningType
+func (r *DownloadsHistoryType) TestCall(rc *butlerd.RequestContext, params butlerd.DownloadsHistoryParams) (*butlerd.DownloadsHistoryResult, error) {
+  var result butlerd.DownloadsHistoryResult
+  err := rc.Call("Downloads.History", params, &result)
+  return &result, err
+}
 
-// LaunchExited (Notification)
+var DownloadsHistory *DownloadsHistoryType
 
-type LaunchExitedType struct {}
+// Downloads.Export (Request)
 
-var _ NotificationMessage = (*LaunchExitedType)(nil)
+type DownloadsExportType struct {}
 
-func (r *LaunchExitedType) Method() string {
-  return "LaunchExited"
+var _ RequestMessage = (*DownloadsExportType)(nil)
+
+func (r *DownloadsExportType) Method() string {
+  return "Downloads.Export"
+}
+
+func (r *DownloadsExportType) Register(router router, f func(*butlerd.RequestContext, butlerd.DownloadsExportParams) (*butlerd.DownloadsExportResult, error)) {
+  router.Register("Downloads.Export", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.DownloadsExportParams
+    err := json.Unmarshal(*rc.Params, &params)
+    if err != nil {
+    	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
+    }
+    err = params.Validate()
+    if err != nil {
+    	return nil, err
+    }
+    res, err := f(rc, params)
+    if err != nil {
+    	return nil, err
+    }
+    if res == nil {
+    	return nil, errors.New("internal error: nil result for Downloads.Export")
+    }
+    return res, nil
+  })
+}
+
+func (r *DownloadsExportType) TestCall(rc *butlerd.RequestContext, params butlerd.DownloadsExportParams) (*butlerd.DownloadsExportResult, error) {
+  var result butlerd.DownloadsExportResult
+  err := rc.Call("Downloads.Export", params, &result)
+  return &result, err
+}
+
+var DownloadsExport *DownloadsExportType
+
+// Downloads.Import (Request)
+
+type DownloadsImportType struct {}
+
+var _ RequestMessage = (*DownloadsImportType)(nil)
+
+func (r *DownloadsImportType) Method() string {
+  return "Downloads.Import"
+}
+
+func (r *DownloadsImportType) Register(router router, f func(*butlerd.RequestContext, butlerd.DownloadsImportParams) (*butlerd.DownloadsImportResult, error)) {
+  router.Register("Downloads.Import", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.DownloadsImportParams
+    err := json.Unmarshal(*rc.Params, &params)
+    if err != nil {
+    	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
+    }
+    err = params.Validate()
+    if err != nil {
+    	return nil, err
+    }
+    res, err := f(rc, params)
+    if err != nil {
+    	return nil, err
+    }
+    if res == nil {
+    	return nil, errors.New("internal error: nil result for Downloads.Import")
+    }
+    return res, nil
+  })
+}
+
+func (r *DownloadsImportType) TestCall(rc *butlerd.RequestContext, params butlerd.DownloadsImportParams) (*butlerd.DownloadsImportResult, error) {
+  var result butlerd.DownloadsImportResult
+  err := rc.Call("Downloads.Import", params, &result)
+  return &result, err
+}
+
+var DownloadsImport *DownloadsImportType
+
+
+//==============================
+// Update
+//==============================
+
+// CheckUpdate (Request)
+
+type CheckUpdateType struct {}
+
+var _ RequestMessage = (*CheckUpdateType)(nil)
+
+func (r *CheckUpdateType) Method() string {
+  return "CheckUpdate"
+}
+
+func (r *CheckUpdateType) Register(router router, f func(*butlerd.RequestContext, butlerd.CheckUpdateParams) (*butlerd.CheckUpdateResult, error)) {
+  router.Register("CheckUpdate", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.CheckUpdateParams
+    err := json.Unmarshal(*rc.Params, &params)
+    if err != nil {
+    	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
+    }
+    err = params.Validate()
+    if err != nil {
+    	return nil, err
+    }
+    res, err := f(rc, params)
+    if err != nil {
+    	return nil, err
+    }
+    if res == nil {
+    	return nil, errors.New("internal error: nil result for CheckUpdate")
+    }
+    return res, nil
+  })
+}
+
+func (r *CheckUpdateType) TestCall(rc *butlerd.RequestContext, params butlerd.CheckUpdateParams) (*butlerd.CheckUpdateResult, error) {
+  var result butlerd.CheckUpdateResult
+  err := rc.Call("CheckUpdate", params, &result)
+  return &result, err
+}
+
+var CheckUpdate *CheckUpdateType
+
+// GameUpdateAvailable (Notification)
+
+type GameUpdateAvailableType struct {}
+
+var _ NotificationMessage = (*GameUpdateAvailableType)(nil)
+
+func (r *GameUpdateAvailableType) Method() string {
+  return "GameUpdateAvailable"
+}
+
+func (r *GameUpdateAvailableType) Notify(rc *butlerd.RequestContext, params butlerd.GameUpdateAvailableNotification) (error) {
+  return rc.Notify("GameUpdateAvailable", params)
+}
+
+func (r *GameUpdateAvailableType) Register(router router, f func(butlerd.GameUpdateAvailableNotification)) {
+  router.RegisterNotification("GameUpdateAvailable", func (notif jsonrpc2.Notification) {
+    var params butlerd.GameUpdateAvailableNotification
+    if notif.Params != nil {
+      err := json.Unmarshal(*notif.Params, &params)
+      if err != nil {
+        return
+      }
+    }
+    f(params)
+  })
+}
+
+var GameUpdateAvailable *GameUpdateAvailableType
+
+// SnoozeCave (Request)
+
+type SnoozeCaveType struct {}
+
+var _ RequestMessage = (*SnoozeCaveType)(nil)
+
+func (r *SnoozeCaveType) Method() string {
+  return "SnoozeCave"
+}
+
+func (r *SnoozeCaveType) Register(router router, f func(*butlerd.RequestContext, butlerd.SnoozeCaveParams) (*butlerd.SnoozeCaveResult, error)) {
+  router.Register("SnoozeCave", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.SnoozeCaveParams
+    err := json.Unmarshal(*rc.Params, &params)
+    if err != nil {
+    	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
+    }
+    err = params.Validate()
+    if err != nil {
+    	return nil, err
+    }
+    res, err := f(rc, params)
+    if err != nil {
+    	return nil, err
+    }
+    if res == nil {
+    	return nil, errors.New("internal error: nil result for SnoozeCave")
+    }
+    return res, nil
+  })
+}
+
+func (r *SnoozeCaveType) TestCall(rc *butlerd.RequestContext, params butlerd.SnoozeCaveParams) (*butlerd.SnoozeCaveResult, error) {
+  var result butlerd.SnoozeCaveResult
+  err := rc.Call("SnoozeCave", params, &result)
+  return &result, err
+}
+
+var SnoozeCave *SnoozeCaveType
+
+
+//==============================
+// update
+//==============================
+
+
+//==============================
+// Launch
+//==============================
+
+// Launch (Request)
+
+type LaunchType struct {}
+
+var _ RequestMessage = (*LaunchType)(nil)
+
+func (r *LaunchType) Method() string {
+  return "Launch"
+}
+
+func (r *LaunchType) Register(router router, f func(*butlerd.RequestContext, butlerd.LaunchParams) (*butlerd.LaunchResult, error)) {
+  router.Register("Launch", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.LaunchParams
+    err := json.Unmarshal(*rc.Params, &params)
+    if err != nil {
+    	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
+    }
+    err = params.Validate()
+    if err != nil {
+    	return nil, err
+    }
+    res, err := f(rc, params)
+    if err != nil {
+    	return nil, err
+    }
+    if res == nil {
+    	return nil, errors.New("internal error: nil result for Launch")
+    }
+    return res, nil
+  })
+}
+
+func (r *LaunchType) TestCall(rc *butlerd.RequestContext, params butlerd.LaunchParams) (*butlerd.LaunchResult, error) {
+  var result butlerd.LaunchResult
+  err := rc.Call("Launch", params, &result)
+  return &result, err
+}
+
+var Launch *LaunchType
+
+// LaunchRunning (Notification)
+
+type LaunchRunningType struct {}
+
+var _ NotificationMessage = (*LaunchRunningType)(nil)
+
+func (r *LaunchRunningType) Method() string {
+  return "LaunchRunning"
+}
+
+func (r *LaunchRunningType) Notify(rc *butlerd.RequestContext, params butlerd.LaunchRunningNotification) (error) {
+  return rc.Notify("LaunchRunning", params)
+}
+
+func (r *LaunchRunningType) Register(router router, f func(butlerd.LaunchRunningNotification)) {
+  router.RegisterNotification("LaunchRunning", func (notif jsonrpc2.Notification) {
+    var params butlerd.LaunchRunningNotification
+    if notif.Params != nil {
+      err := json.Unmarshal(*notif.Params, &params)
+      if err != nil {
+        return
+      }
+    }
+    f(params)
+  })
+}
+
+var LaunchRunning *LaunchRunningType
+
+// LaunchExited (Notification)
+
+type LaunchExitedType struct {}
+
+var _ NotificationMessage = (*LaunchExitedType)(nil)
+
+func (r *LaunchExitedType) Method() string {
+  return "LaunchExited"
 }
 
 func (r *LaunchExitedType) Notify(rc *butlerd.RequestContext, params butlerd.LaunchExitedNotification) (error) {
@@ -2934,11 +3403,51 @@ func (r *LaunchExitedType) Register(router router, f func(butlerd.LaunchExitedNo
         return
       }
     }
-    f(params)
+    f(params)
+  })
+}
+
+var LaunchExited *LaunchExitedType
+
+// Launch.Sessions.List (Request)
+
+type LaunchSessionsListType struct {}
+
+var _ RequestMessage = (*LaunchSessionsListType)(nil)
+
+func (r *LaunchSessionsListType) Method() string {
+  return "Launch.Sessions.List"
+}
+
+func (r *LaunchSessionsListType) Register(router router, f func(*butlerd.RequestContext, butlerd.LaunchSessionsListParams) (*butlerd.LaunchSessionsListResult, error)) {
+  router.Register("Launch.Sessions.List", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.LaunchSessionsListParams
+    err := json.Unmarshal(*rc.Params, &params)
+    if err != nil {
+    	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
+    }
+    err = params.Validate()
+    if err != nil {
+    	return nil, err
+    }
+    res, err := f(rc, params)
+    if err != nil {
+    	return nil, err
+    }
+    if res == nil {
+    	return nil, errors.New("internal error: nil result for Launch.Sessions.List")
+    }
+    return res, nil
   })
 }
 
-var LaunchExited *LaunchExitedType
+func (r *LaunchSessionsListType) TestCall(rc *butlerd.RequestContext, params butlerd.LaunchSessionsListParams) (*butlerd.LaunchSessionsListResult, error) {
+  var result butlerd.LaunchSessionsListResult
+  err := rc.Call("Launch.Sessions.List", params, &result)
+  return &result, err
+}
+
+var LaunchSessionsList *LaunchSessionsListType
 
 // AcceptLicense (Request)
 
@@ -3393,6 +3902,131 @@ func (r *CleanDownloadsApplyType) TestCall(rc *butlerd.RequestContext, params bu
 var CleanDownloadsApply *CleanDownloadsApplyType
 
 
+//==============================
+// Hosts
+//==============================
+
+// Hosts.List (Request)
+
+type HostsListType struct {}
+
+var _ RequestMessage = (*HostsListType)(nil)
+
+func (r *HostsListType) Method() string {
+  return "Hosts.List"
+}
+
+func (r *HostsListType) Register(router router, f func(*butlerd.RequestContext, butlerd.HostsListParams) (*butlerd.HostsListResult, error)) {
+  router.Register("Hosts.List", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.HostsListParams
+    err := json.Unmarshal(*rc.Params, &params)
+    if err != nil {
+    	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
+    }
+    err = params.Validate()
+    if err != nil {
+    	return nil, err
+    }
+    res, err := f(rc, params)
+    if err != nil {
+    	return nil, err
+    }
+    if res == nil {
+    	return nil, errors.New("internal error: nil result for Hosts.List")
+    }
+    return res, nil
+  })
+}
+
+func (r *HostsListType) TestCall(rc *butlerd.RequestContext, params butlerd.HostsListParams) (*butlerd.HostsListResult, error) {
+  var result butlerd.HostsListResult
+  err := rc.Call("Hosts.List", params, &result)
+  return &result, err
+}
+
+var HostsList *HostsListType
+
+// Hosts.Add (Request)
+
+type HostsAddType struct {}
+
+var _ RequestMessage = (*HostsAddType)(nil)
+
+func (r *HostsAddType) Method() string {
+  return "Hosts.Add"
+}
+
+func (r *HostsAddType) Register(router router, f func(*butlerd.RequestContext, butlerd.HostsAddParams) (*butlerd.HostsAddResult, error)) {
+  router.Register("Hosts.Add", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.HostsAddParams
+    err := json.Unmarshal(*rc.Params, &params)
+    if err != nil {
+    	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
+    }
+    err = params.Validate()
+    if err != nil {
+    	return nil, err
+    }
+    res, err := f(rc, params)
+    if err != nil {
+    	return nil, err
+    }
+    if res == nil {
+    	return nil, errors.New("internal error: nil result for Hosts.Add")
+    }
+    return res, nil
+  })
+}
+
+func (r *HostsAddType) TestCall(rc *butlerd.RequestContext, params butlerd.HostsAddParams) (*butlerd.HostsAddResult, error) {
+  var result butlerd.HostsAddResult
+  err := rc.Call("Hosts.Add", params, &result)
+  return &result, err
+}
+
+var HostsAdd *HostsAddType
+
+// Hosts.Remove (Request)
+
+type HostsRemoveType struct {}
+
+var _ RequestMessage = (*HostsRemoveType)(nil)
+
+func (r *HostsRemoveType) Method() string {
+  return "Hosts.Remove"
+}
+
+func (r *HostsRemoveType) Register(router router, f func(*butlerd.RequestContext, butlerd.HostsRemoveParams) (*butlerd.HostsRemoveResult, error)) {
+  router.Register("Hosts.Remove", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.HostsRemoveParams
+    err := json.Unmarshal(*rc.Params, &params)
+    if err != nil {
+    	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
+    }
+    err = params.Validate()
+    if err != nil {
+    	return nil, err
+    }
+    res, err := f(rc, params)
+    if err != nil {
+    	return nil, err
+    }
+    if res == nil {
+    	return nil, errors.New("internal error: nil result for Hosts.Remove")
+    }
+    return res, nil
+  })
+}
+
+func (r *HostsRemoveType) TestCall(rc *butlerd.RequestContext, params butlerd.HostsRemoveParams) (*butlerd.HostsRemoveResult, error) {
+  var result butlerd.HostsRemoveResult
+  err := rc.Call("Hosts.Remove", params, &result)
+  return &result, err
+}
+
+var HostsRemove *HostsRemoveType
+
+
 //==============================
 // System
 //==============================
@@ -3438,6 +4072,234 @@ func (r *SystemStatFSType) TestCall(rc *butlerd.RequestContext, params butlerd.S
 var SystemStatFS *SystemStatFSType
 
 
+//==============================
+// Events
+//==============================
+
+// Events.Subscribe (Request)
+
+type EventsSubscribeType struct {}
+
+var _ RequestMessage = (*EventsSubscribeType)(nil)
+
+func (r *EventsSubscribeType) Method() string {
+  return "Events.Subscribe"
+}
+
+func (r *EventsSubscribeType) Register(router router, f func(*butlerd.RequestContext, butlerd.EventsSubscribeParams) (*butlerd.EventsSubscribeResult, error)) {
+  router.Register("Events.Subscribe", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.EventsSubscribeParams
+    err := json.Unmarshal(*rc.Params, &params)
+    if err != nil {
+    	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
+    }
+    err = params.Validate()
+    if err != nil {
+    	return nil, err
+    }
+    res, err := f(rc, params)
+    if err != nil {
+    	return nil, err
+    }
+    if res == nil {
+    	return nil, errors.New("internal error: nil result for Events.Subscribe")
+    }
+    return res, nil
+  })
+}
+
+func (r *EventsSubscribeType) TestCall(rc *butlerd.RequestContext, params butlerd.EventsSubscribeParams) (*butlerd.EventsSubscribeResult, error) {
+  var result butlerd.EventsSubscribeResult
+  err := rc.Call("Events.Subscribe", params, &result)
+  return &result, err
+}
+
+var EventsSubscribe *EventsSubscribeType
+
+// Events.Event (Notification)
+
+type EventsEventType struct {}
+
+var _ NotificationMessage = (*EventsEventType)(nil)
+
+func (r *EventsEventType) Method() string {
+  return "Events.Event"
+}
+
+func (r *EventsEventType) Notify(rc *butlerd.RequestContext, params butlerd.EventsEventNotification) (error) {
+  return rc.Notify("Events.Event", params)
+}
+
+func (r *EventsEventType) Register(router router, f func(butlerd.EventsEventNotification)) {
+  router.RegisterNotification("Events.Event", func (notif jsonrpc2.Notification) {
+    var params butlerd.EventsEventNotification
+    if notif.Params != nil {
+      err := json.Unmarshal(*notif.Params, &params)
+      if err != nil {
+        return
+      }
+    }
+    f(params)
+  })
+}
+
+var EventsEvent *EventsEventType
+
+// Events.Dropped (Notification)
+
+type EventsDroppedType struct {}
+
+var _ NotificationMessage = (*EventsDroppedType)(nil)
+
+func (r *EventsDroppedType) Method() string {
+  return "Events.Dropped"
+}
+
+func (r *EventsDroppedType) Notify(rc *butlerd.RequestContext, params butlerd.EventsDroppedNotification) (error) {
+  return rc.Notify("Events.Dropped", params)
+}
+
+func (r *EventsDroppedType) Register(router router, f func(butlerd.EventsDroppedNotification)) {
+  router.RegisterNotification("Events.Dropped", func (notif jsonrpc2.Notification) {
+    var params butlerd.EventsDroppedNotification
+    if notif.Params != nil {
+      err := json.Unmarshal(*notif.Params, &params)
+      if err != nil {
+        return
+      }
+    }
+    f(params)
+  })
+}
+
+var EventsDropped *EventsDroppedType
+
+// Events.Unsubscribe (Request)
+
+type EventsUnsubscribeType struct {}
+
+var _ RequestMessage = (*EventsUnsubscribeType)(nil)
+
+func (r *EventsUnsubscribeType) Method() string {
+  return "Events.Unsubscribe"
+}
+
+func (r *EventsUnsubscribeType) Register(router router, f func(*butlerd.RequestContext, butlerd.EventsUnsubscribeParams) (*butlerd.EventsUnsubscribeResult, error)) {
+  router.Register("Events.Unsubscribe", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.EventsUnsubscribeParams
+    err := json.Unmarshal(*rc.Params, &params)
+    if err != nil {
+    	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
+    }
+    err = params.Validate()
+    if err != nil {
+    	return nil, err
+    }
+    res, err := f(rc, params)
+    if err != nil {
+    	return nil, err
+    }
+    if res == nil {
+    	return nil, errors.New("internal error: nil result for Events.Unsubscribe")
+    }
+    return res, nil
+  })
+}
+
+func (r *EventsUnsubscribeType) TestCall(rc *butlerd.RequestContext, params butlerd.EventsUnsubscribeParams) (*butlerd.EventsUnsubscribeResult, error) {
+  var result butlerd.EventsUnsubscribeResult
+  err := rc.Call("Events.Unsubscribe", params, &result)
+  return &result, err
+}
+
+var EventsUnsubscribe *EventsUnsubscribeType
+
+
+//==============================
+// Tasks
+//==============================
+
+// Tasks.List (Request)
+
+type TasksListType struct {}
+
+var _ RequestMessage = (*TasksListType)(nil)
+
+func (r *TasksListType) Method() string {
+  return "Tasks.List"
+}
+
+func (r *TasksListType) Register(router router, f func(*butlerd.RequestContext, butlerd.TasksListParams) (*butlerd.TasksListResult, error)) {
+  router.Register("Tasks.List", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.TasksListParams
+    err := json.Unmarshal(*rc.Params, &params)
+    if err != nil {
+    	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
+    }
+    err = params.Validate()
+    if err != nil {
+    	return nil, err
+    }
+    res, err := f(rc, params)
+    if err != nil {
+    	return nil, err
+    }
+    if res == nil {
+    	return nil, errors.New("internal error: nil result for Tasks.List")
+    }
+    return res, nil
+  })
+}
+
+func (r *TasksListType) TestCall(rc *butlerd.RequestContext, params butlerd.TasksListParams) (*butlerd.TasksListResult, error) {
+  var result butlerd.TasksListResult
+  err := rc.Call("Tasks.List", params, &result)
+  return &result, err
+}
+
+var TasksList *TasksListType
+
+// Tasks.Cancel (Request)
+
+type TasksCancelType struct {}
+
+var _ RequestMessage = (*TasksCancelType)(nil)
+
+func (r *TasksCancelType) Method() string {
+  return "Tasks.Cancel"
+}
+
+func (r *TasksCancelType) Register(router router, f func(*butlerd.RequestContext, butlerd.TasksCancelParams) (*butlerd.TasksCancelResult, error)) {
+  router.Register("Tasks.Cancel", func (rc *butlerd.RequestContext) (interface{}, error) {
+    var params butlerd.TasksCancelParams
+    err := json.Unmarshal(*rc.Params, &params)
+    if err != nil {
+    	return nil, &butlerd.RpcError{Code: jsonrpc2.CodeParseError, Message: err.Error()}
+    }
+    err = params.Validate()
+    if err != nil {
+    	return nil, err
+    }
+    res, err := f(rc, params)
+    if err != nil {
+    	return nil, err
+    }
+    if res == nil {
+    	return nil, errors.New("internal error: nil result for Tasks.Cancel")
+    }
+    return res, nil
+  })
+}
+
+func (r *TasksCancelType) TestCall(rc *butlerd.RequestContext, params butlerd.TasksCancelParams) (*butlerd.TasksCancelResult, error) {
+  var result butlerd.TasksCancelResult
+  err := rc.Call("Tasks.Cancel", params, &result)
+  return &result, err
+}
+
+var TasksCancel *TasksCancelType
+
+
 //==============================
 // Test
 //==============================
@@ -3527,9 +4389,11 @@ func EnsureAllRequests(router *butlerd.Router) {
   if _, ok := router.Handlers["Meta.Authenticate"]; !ok { panic("missing request handler for (Meta.Authenticate)") }
   if _, ok := router.Handlers["Meta.Flow"]; !ok { panic("missing request handler for (Meta.Flow)") }
   if _, ok := router.Handlers["Meta.Shutdown"]; !ok { panic("missing request handler for (Meta.Shutdown)") }
+  if _, ok := router.Handlers["Meta.Introspect"]; !ok { panic("missing request handler for (Meta.Introspect)") }
   if _, ok := router.Handlers["Version.Get"]; !ok { panic("missing request handler for (Version.Get)") }
   if _, ok := router.Handlers["Network.SetSimulateOffline"]; !ok { panic("missing request handler for (Network.SetSimulateOffline)") }
   if _, ok := router.Handlers["Network.SetBandwidthThrottle"]; !ok { panic("missing request handler for (Network.SetBandwidthThrottle)") }
+  if _, ok := router.Handlers["Network.GetStatus"]; !ok { panic("missing request handler for (Network.GetStatus)") }
   if _, ok := router.Handlers["Profile.List"]; !ok { panic("missing request handler for (Profile.List)") }
   if _, ok := router.Handlers["Profile.LoginWithPassword"]; !ok { panic("missing request handler for (Profile.LoginWithPassword)") }
   if _, ok := router.Handlers["Profile.LoginWithAPIKey"]; !ok { panic("missing request handler for (Profile.LoginWithAPIKey)") }
@@ -3559,6 +4423,7 @@ func EnsureAllRequests(router *butlerd.Router) {
   if _, ok := router.Handlers["Install.Queue"]; !ok { panic("missing request handler for (Install.Queue)") }
   if _, ok := router.Handlers["Install.Plan"]; !ok { panic("missing request handler for (Install.Plan)") }
   if _, ok := router.Handlers["Caves.SetPinned"]; !ok { panic("missing request handler for (Caves.SetPinned)") }
+  if _, ok := router.Handlers["Caves.SetLaunchOverrides"]; !ok { panic("missing request handler for (Caves.SetLaunchOverrides)") }
   if _, ok := router.Handlers["Install.CreateShortcut"]; !ok { panic("missing request handler for (Install.CreateShortcut)") }
   if _, ok := router.Handlers["Install.Perform"]; !ok { panic("missing request handler for (Install.Perform)") }
   if _, ok := router.Handlers["Install.Cancel"]; !ok { panic("missing request handler for (Install.Cancel)") }
@@ -3566,6 +4431,7 @@ func EnsureAllRequests(router *butlerd.Router) {
   if _, ok := router.Handlers["Install.VersionSwitch.Queue"]; !ok { panic("missing request handler for (Install.VersionSwitch.Queue)") }
   if _, ok := router.Handlers["Install.Locations.List"]; !ok { panic("missing request handler for (Install.Locations.List)") }
   if _, ok := router.Handlers["Install.Locations.Add"]; !ok { panic("missing request handler for (Install.Locations.Add)") }
+  if _, ok := router.Handlers["Install.Locations.SetVerifyInstalls"]; !ok { panic("missing request handler for (Install.Locations.SetVerifyInstalls)") }
   if _, ok := router.Handlers["Install.Locations.Remove"]; !ok { panic("missing request handler for (Install.Locations.Remove)") }
   if _, ok := router.Handlers["Install.Locations.GetByID"]; !ok { panic("missing request handler for (Install.Locations.GetByID)") }
   if _, ok := router.Handlers["Install.Locations.Scan"]; !ok { panic("missing request handler for (Install.Locations.Scan)") }
@@ -3577,12 +4443,27 @@ func EnsureAllRequests(router *butlerd.Router) {
   if _, ok := router.Handlers["Downloads.Drive.Cancel"]; !ok { panic("missing request handler for (Downloads.Drive.Cancel)") }
   if _, ok := router.Handlers["Downloads.Retry"]; !ok { panic("missing request handler for (Downloads.Retry)") }
   if _, ok := router.Handlers["Downloads.Discard"]; !ok { panic("missing request handler for (Downloads.Discard)") }
+  if _, ok := router.Handlers["Downloads.Pause"]; !ok { panic("missing request handler for (Downloads.Pause)") }
+  if _, ok := router.Handlers["Downloads.Resume"]; !ok { panic("missing request handler for (Downloads.Resume)") }
+  if _, ok := router.Handlers["Downloads.GetSchedule"]; !ok { panic("missing request handler for (Downloads.GetSchedule)") }
+  if _, ok := router.Handlers["Downloads.SetSchedule"]; !ok { panic("missing request handler for (Downloads.SetSchedule)") }
+  if _, ok := router.Handlers["Downloads.History"]; !ok { panic("missing request handler for (Downloads.History)") }
+  if _, ok := router.Handlers["Downloads.Export"]; !ok { panic("missing request handler for (Downloads.Export)") }
+  if _, ok := router.Handlers["Downloads.Import"]; !ok { panic("missing request handler for (Downloads.Import)") }
   if _, ok := router.Handlers["CheckUpdate"]; !ok { panic("missing request handler for (CheckUpdate)") }
   if _, ok := router.Handlers["SnoozeCave"]; !ok { panic("missing request handler for (SnoozeCave)") }
   if _, ok := router.Handlers["Launch"]; !ok { panic("missing request handler for (Launch)") }
+  if _, ok := router.Handlers["Launch.Sessions.List"]; !ok { panic("missing request handler for (Launch.Sessions.List)") }
   if _, ok := router.Handlers["CleanDownloads.Search"]; !ok { panic("missing request handler for (CleanDownloads.Search)") }
   if _, ok := router.Handlers["CleanDownloads.Apply"]; !ok { panic("missing request handler for (CleanDownloads.Apply)") }
+  if _, ok := router.Handlers["Hosts.List"]; !ok { panic("missing request handler for (Hosts.List)") }
+  if _, ok := router.Handlers["Hosts.Add"]; !ok { panic("missing request handler for (Hosts.Add)") }
+  if _, ok := router.Handlers["Hosts.Remove"]; !ok { panic("missing request handler for (Hosts.Remove)") }
   if _, ok := router.Handlers["System.StatFS"]; !ok { panic("missing request handler for (System.StatFS)") }
+  if _, ok := router.Handlers["Events.Subscribe"]; !ok { panic("missing request handler for (Events.Subscribe)") }
+  if _, ok := router.Handlers["Events.Unsubscribe"]; !ok { panic("missing request handler for (Events.Unsubscribe)") }
+  if _, ok := router.Handlers["Tasks.List"]; !ok { panic("missing request handler for (Tasks.List)") }
+  if _, ok := router.Handlers["Tasks.Cancel"]; !ok { panic("missing request handler for (Tasks.Cancel)") }
   if _, ok := router.Handlers["Test.DoubleTwice"]; !ok { panic("missing request handler for (Test.DoubleTwice)") }
 }
 
@@ -0,0 +1,50 @@
+package butlerd
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/itchio/butler/butlerd/metrics"
+)
+
+var (
+	requestsTotal = metrics.Default.NewCounter("butlerd_requests_total",
+		"Requests handled, by method and error code (0 for success)",
+		"method", "code")
+	requestDurationSeconds = metrics.Default.NewHistogram("butlerd_request_duration_seconds",
+		"How long requests took to complete, by method",
+		metrics.DefaultBuckets, "method")
+	dbConnWaitSeconds = metrics.Default.NewHistogram("butlerd_db_conn_wait_seconds",
+		"How long requests waited to get a connection from the sqlite pool",
+		metrics.DefaultBuckets)
+)
+
+// MetricsMiddleware records the number, duration and outcome of all
+// requests handled by a router in metrics.Default.
+func MetricsMiddleware() Middleware {
+	return Middleware{
+		Request: func(method string, next RequestHandler) RequestHandler {
+			return func(rc *RequestContext) (interface{}, error) {
+				startTime := time.Now()
+				res, err := next(rc)
+
+				var code int64
+				if err != nil {
+					code, _, _ = classifyError(err)
+				}
+				requestsTotal.Inc(method, strconv.FormatInt(code, 10))
+				requestDurationSeconds.Observe(time.Since(startTime).Seconds(), method)
+				return res, err
+			}
+		},
+	}
+}
+
+// RegisterMetrics exposes the router's internal state in metrics.Default.
+func (r *Router) RegisterMetrics() {
+	metrics.Default.NewGaugeFunc("butlerd_inflight_background_tasks",
+		"Background tasks queued or currently running",
+		func() float64 {
+			return float64(r.NumInflightBackgroundTasks())
+		})
+}
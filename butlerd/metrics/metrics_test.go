@@ -0,0 +1,47 @@
+package metrics_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/itchio/butler/butlerd/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteText(t *testing.T) {
+	assert := assert.New(t)
+
+	r := metrics.NewRegistry()
+	requests := r.NewCounter("requests_total", "Requests handled", "method")
+	requests.Inc("Version.Get")
+	requests.Add(2, `Weird "method"`)
+
+	durations := r.NewHistogram("duration_seconds", "Request durations", []float64{0.1, 1})
+	durations.Observe(0.05)
+	durations.Observe(0.5)
+	durations.Observe(5)
+
+	r.NewGaugeFunc("tasks", "Tasks in flight", func() float64 { return 3 })
+
+	assert.Panics(func() {
+		r.NewCounter("tasks", "Duplicate")
+	})
+
+	var buf bytes.Buffer
+	assert.NoError(r.WriteText(&buf))
+	assert.EqualValues(`# HELP requests_total Requests handled
+# TYPE requests_total counter
+requests_total{method="Version.Get"} 1
+requests_total{method="Weird \"method\""} 2
+# HELP duration_seconds Request durations
+# TYPE duration_seconds histogram
+duration_seconds_bucket{le="0.1"} 1
+duration_seconds_bucket{le="1"} 2
+duration_seconds_bucket{le="+Inf"} 3
+duration_seconds_sum 5.55
+duration_seconds_count 3
+# HELP tasks Tasks in flight
+# TYPE tasks gauge
+tasks 3
+`, buf.String())
+}
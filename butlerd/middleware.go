@@ -0,0 +1,74 @@
+package butlerd
+
+import (
+	"time"
+
+	"github.com/itchio/butler/butlerd/jsonrpc2"
+	"github.com/itchio/headway/state"
+)
+
+// A Middleware wraps the handlers of a Router, so that cross-cutting
+// concerns (logging, timing, concurrency limits, access checks, etc.)
+// don't have to be implemented by each endpoint.
+//
+// Both fields are optional.
+type Middleware struct {
+	// Wraps the handler for requests of the given method. Note that
+	// requests for unknown methods go through it as well.
+	Request func(method string, next RequestHandler) RequestHandler
+
+	// Wraps the handler for notifications of the given method.
+	Notification func(method string, next NotificationHandler) NotificationHandler
+}
+
+// Use adds a middleware to the router. Middlewares wrap handlers
+// in the order they were added: the first one added is the outermost.
+//
+// Like Register, it must be called before the router starts serving.
+func (r *Router) Use(mw Middleware) {
+	r.middlewares = append(r.middlewares, mw)
+}
+
+func (r *Router) wrapRequestHandler(method string, h RequestHandler) RequestHandler {
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		if wrap := r.middlewares[i].Request; wrap != nil {
+			h = wrap(method, h)
+		}
+	}
+	return h
+}
+
+func (r *Router) wrapNotificationHandler(method string, h NotificationHandler) NotificationHandler {
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		if wrap := r.middlewares[i].Notification; wrap != nil {
+			h = wrap(method, h)
+		}
+	}
+	return h
+}
+
+// LogMiddleware logs every request along with how long it took and
+// whether it failed, and every notification received.
+func LogMiddleware(consumer *state.Consumer) Middleware {
+	return Middleware{
+		Request: func(method string, next RequestHandler) RequestHandler {
+			return func(rc *RequestContext) (interface{}, error) {
+				startTime := time.Now()
+				consumer.Infof("→ %s", method)
+				res, err := next(rc)
+				if err != nil {
+					consumer.Warnf("✗ %s (%v): %v", method, time.Since(startTime), err)
+				} else {
+					consumer.Infof("✓ %s (%v)", method, time.Since(startTime))
+				}
+				return res, err
+			}
+		},
+		Notification: func(method string, next NotificationHandler) NotificationHandler {
+			return func(notif jsonrpc2.Notification) {
+				consumer.Infof("← %s", method)
+				next(notif)
+			}
+		},
+	}
+}
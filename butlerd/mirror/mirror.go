@@ -0,0 +1,99 @@
+// Package mirror lets build files (archives, patches and signatures) be
+// fetched from a local cache folder or a mirror instead of itch.io, for
+// example when a lot of machines on the same network install the same build.
+//
+// Files are keyed by build ID, type and subtype, and laid out the same way
+// in the cache folder and on the mirror, so one machine's cache folder can
+// be served over HTTP as a mirror for the others. The mirror must support
+// HTTP range requests.
+//
+// Files found there are only as trustworthy as the mirror: callers must
+// check them against what itch.io says about the build.
+package mirror
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	itchio "github.com/itchio/go-itchio"
+)
+
+// A Config describes where build files are looked up before itch.io.
+// Either field may be empty.
+type Config struct {
+	// Folder where build files are cached
+	CacheDir string
+	// Base URL of a mirror, like "http://192.168.1.10:8080/builds"
+	BaseURL string
+}
+
+var config Config
+var lock sync.Mutex
+
+func SetCacheDir(dir string) {
+	lock.Lock()
+	defer lock.Unlock()
+	config.CacheDir = dir
+}
+
+func SetBaseURL(url string) {
+	lock.Lock()
+	defer lock.Unlock()
+	config.BaseURL = strings.TrimSuffix(url, "/")
+}
+
+func Get() Config {
+	lock.Lock()
+	defer lock.Unlock()
+	return config
+}
+
+// Enabled returns true if there's a cache folder or a mirror
+func (c Config) Enabled() bool {
+	return c.CacheDir != "" || c.BaseURL != ""
+}
+
+// Key returns the slash-separated path of a build file, relative to the
+// cache folder or mirror, like "1234/archive-default"
+func Key(buildID int64, fileType itchio.BuildFileType, subType itchio.BuildFileSubType) string {
+	if subType == "" {
+		subType = itchio.BuildFileSubTypeDefault
+	}
+	return fmt.Sprintf("%d/%s-%s", buildID, fileType, subType)
+}
+
+// CachePath returns where a build file is (or would be) cached, or
+// an empty string if there's no cache folder.
+func (c Config) CachePath(buildID int64, fileType itchio.BuildFileType, subType itchio.BuildFileSubType) string {
+	if c.CacheDir == "" {
+		return ""
+	}
+	return filepath.Join(c.CacheDir, filepath.FromSlash(Key(buildID, fileType, subType)))
+}
+
+// URL returns the URL of a build file on the mirror, or an empty string
+// if there's no mirror.
+func (c Config) URL(buildID int64, fileType itchio.BuildFileType, subType itchio.BuildFileSubType) string {
+	if c.BaseURL == "" {
+		return ""
+	}
+	return c.BaseURL + "/" + Key(buildID, fileType, subType)
+}
+
+// IsCached returns true if a build file of the given size is in the
+// cache folder. Files of any other size are partial or stale.
+func (c Config) IsCached(buildID int64, fileType itchio.BuildFileType, subType itchio.BuildFileSubType, size int64) bool {
+	cachePath := c.CachePath(buildID, fileType, subType)
+	if cachePath == "" {
+		return false
+	}
+
+	stats, err := os.Stat(cachePath)
+	if err != nil {
+		return false
+	}
+	return stats.Mode().IsRegular() && stats.Size() == size
+}
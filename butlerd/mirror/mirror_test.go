@@ -0,0 +1,53 @@
+package mirror_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/itchio/butler/butlerd/mirror"
+	itchio "github.com/itchio/go-itchio"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(mirror.Get().Enabled())
+	assert.EqualValues("", mirror.Get().CachePath(1234, itchio.BuildFileTypeArchive, ""))
+	assert.EqualValues("", mirror.Get().URL(1234, itchio.BuildFileTypeArchive, ""))
+
+	mirror.SetBaseURL("http://192.168.1.10:8080/builds/")
+	defer mirror.SetBaseURL("")
+	assert.True(mirror.Get().Enabled())
+	assert.EqualValues("http://192.168.1.10:8080/builds/1234/archive-default", mirror.Get().URL(1234, itchio.BuildFileTypeArchive, ""))
+	assert.EqualValues("http://192.168.1.10:8080/builds/1234/patch-optimized", mirror.Get().URL(1234, itchio.BuildFileTypePatch, itchio.BuildFileSubTypeOptimized))
+}
+
+func TestIsCached(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "mirror-test")
+	must(t, err)
+	defer os.RemoveAll(dir)
+
+	mirror.SetCacheDir(dir)
+	defer mirror.SetCacheDir("")
+
+	c := mirror.Get()
+	cachePath := c.CachePath(1234, itchio.BuildFileTypeSignature, "")
+	assert.EqualValues(filepath.Join(dir, "1234", "signature-default"), cachePath)
+	assert.False(c.IsCached(1234, itchio.BuildFileTypeSignature, "", 5))
+
+	must(t, os.MkdirAll(filepath.Dir(cachePath), 0o755))
+	must(t, ioutil.WriteFile(cachePath, []byte("hello"), 0o644))
+	assert.True(c.IsCached(1234, itchio.BuildFileTypeSignature, "", 5))
+	assert.False(c.IsCached(1234, itchio.BuildFileTypeSignature, "", 6), "size mismatch means partial or stale")
+}
+
+func must(t *testing.T, err error) {
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+}
@@ -0,0 +1,183 @@
+// Package netstatus keeps track of whether butler can reach the network,
+// by probing a URL when something reports a network failure.
+//
+// States go from online to offline when a network failure is reported,
+// from offline to online when a probe succeeds, and from offline to
+// unreachable when probes keep failing for too long. Probing starts
+// again (from unreachable to offline) the next time something waits for
+// the network.
+package netstatus
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/itchio/httpkit/timeout"
+	"github.com/pkg/errors"
+)
+
+type Status string
+
+const (
+	Online      Status = "online"
+	Offline     Status = "offline"
+	Unreachable Status = "unreachable"
+)
+
+// DefaultProbeURL is probed unless another one is set with SetProbeURL.
+// Any HTTP response, whatever its status code, means we're online.
+const DefaultProbeURL = "https://itch.io/static/ping.txt"
+
+const (
+	initialProbeDelay = 1 * time.Second
+	maxProbeDelay     = 30 * time.Second
+	giveUpAfter       = 5 * time.Minute
+)
+
+// ErrGaveUp is the cause of the error returned by WaitOnline when
+// probing has failed for too long.
+var ErrGaveUp = errors.New("gave up waiting for network")
+
+// A Snapshot describes the network status at a given time.
+type Snapshot struct {
+	Status          Status
+	ProbeURL        string
+	SimulateOffline bool
+	// Number of probes that failed in a row
+	FailedProbes int64
+	LastProbeAt  *time.Time
+	NextProbeAt  *time.Time
+	LastError    string
+}
+
+var state = Snapshot{
+	Status:   Online,
+	ProbeURL: DefaultProbeURL,
+}
+var lock sync.Mutex
+
+var client = timeout.NewDefaultClient()
+
+// SetProbeURL changes which URL is probed, for example to use a
+// local stand-in when itch.io isn't reachable on purpose.
+func SetProbeURL(url string) {
+	lock.Lock()
+	defer lock.Unlock()
+	state.ProbeURL = url
+}
+
+// SetSimulateOffline makes all network connections fail, see
+// timeout.SetSimulateOffline, and probes fail without trying.
+func SetSimulateOffline(enabled bool) {
+	timeout.SetSimulateOffline(enabled)
+
+	lock.Lock()
+	defer lock.Unlock()
+	state.SimulateOffline = enabled
+}
+
+// Get returns the current network status.
+func Get() Snapshot {
+	lock.Lock()
+	defer lock.Unlock()
+	return state
+}
+
+// ReportOffline is called when an operation failed because of the network.
+func ReportOffline(err error) {
+	lock.Lock()
+	defer lock.Unlock()
+	state.Status = Offline
+	if err != nil {
+		state.LastError = err.Error()
+	}
+}
+
+// Probe tries to reach the probe URL once, and updates the status.
+func Probe(ctx context.Context) error {
+	lock.Lock()
+	probeURL := state.ProbeURL
+	simulateOffline := state.SimulateOffline
+	lock.Unlock()
+
+	err := func() error {
+		if simulateOffline {
+			return errors.New("simulated offline")
+		}
+
+		req, err := http.NewRequest("GET", probeURL, nil)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		res, err := client.Do(req.WithContext(ctx))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		res.Body.Close()
+		return nil
+	}()
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	now := time.Now().UTC()
+	state.LastProbeAt = &now
+	state.NextProbeAt = nil
+	if err != nil {
+		state.FailedProbes++
+		state.LastError = err.Error()
+		return err
+	}
+
+	state.Status = Online
+	state.FailedProbes = 0
+	state.LastError = ""
+	return nil
+}
+
+// WaitOnline probes with exponential backoff until a probe succeeds,
+// ctx is done, or it's been failing for too long, in which case the
+// status becomes unreachable and an error caused by ErrGaveUp is returned.
+func WaitOnline(ctx context.Context) error {
+	lock.Lock()
+	state.Status = Offline
+	lock.Unlock()
+
+	startedAt := time.Now()
+	delay := initialProbeDelay
+	for {
+		err := Probe(ctx)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return errors.WithStack(ctx.Err())
+		}
+
+		if time.Since(startedAt)+delay > giveUpAfter {
+			lock.Lock()
+			state.Status = Unreachable
+			numProbes := state.FailedProbes
+			lock.Unlock()
+			return errors.Wrapf(ErrGaveUp, "after %d probes (last error: %v)", numProbes, err)
+		}
+
+		nextProbeAt := time.Now().UTC().Add(delay)
+		lock.Lock()
+		state.NextProbeAt = &nextProbeAt
+		lock.Unlock()
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return errors.WithStack(ctx.Err())
+		}
+
+		delay *= 2
+		if delay > maxProbeDelay {
+			delay = maxProbeDelay
+		}
+	}
+}
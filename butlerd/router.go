@@ -10,7 +10,7 @@ import (
 	"golang.org/x/sync/singleflight"
 
 	"github.com/itchio/butler/buildinfo"
-	"github.com/itchio/butler/butlerd/horror"
+	"github.com/itchio/butler/butlerd/bandwidth"
 	"github.com/itchio/butler/butlerd/jsonrpc2"
 	"github.com/itchio/butler/comm"
 	"github.com/itchio/butler/database/models"
@@ -33,16 +33,24 @@ type InFlightRequest struct {
 	Desc         string
 }
 
-type BackgroundTaskID int64
+type BackgroundTaskID string
 
 type InFlightBackgroundTask struct {
 	QueuedAt time.Time
 	Desc     string
+
+	cancel context.CancelFunc
 }
 
+// A BackgroundTask is persisted in the database, so it can be
+// retried if it fails, and resumed if butlerd shuts down before
+// it completes. See RegisterBackgroundTask.
 type BackgroundTask struct {
-	Desc string
-	Do   func(rc *RequestContext) error
+	// Which handler the task is run with
+	Kind string
+	// Parameters for the handler, must be serializable to JSON
+	Params interface{}
+	Desc   string
 }
 
 type RequestHandler func(rc *RequestContext) (interface{}, error)
@@ -71,7 +79,12 @@ type Router struct {
 	inflightBackgroundTasks map[BackgroundTaskID]InFlightBackgroundTask
 	inflightLock            sync.Mutex
 
-	backgroundTaskIDSeed BackgroundTaskID
+	backgroundTaskHandlers map[string]BackgroundTaskFunc
+	backgroundTasksLock    sync.Mutex
+
+	middlewares []Middleware
+
+	events *eventHub
 
 	globalConsumer *state.Consumer
 }
@@ -99,7 +112,9 @@ func NewRouter(dbPool *sqlitex.Pool, getClient GetClientFunc, httpClient *http.C
 		Group:        &singleflight.Group{},
 		ShutdownChan: make(chan struct{}),
 
-		backgroundTaskIDSeed: 0,
+		backgroundTaskHandlers: make(map[string]BackgroundTaskFunc),
+
+		events: newEventHub(),
 
 		globalConsumer: &state.Consumer{
 			OnMessage: func(lvl string, msg string) {
@@ -129,6 +144,14 @@ func (r *Router) initiateShutdown() {
 	})
 }
 
+// NumInflightBackgroundTasks returns the number of background tasks
+// queued or currently running.
+func (r *Router) NumInflightBackgroundTasks() int {
+	r.inflightLock.Lock()
+	defer r.inflightLock.Unlock()
+	return len(r.inflightBackgroundTasks)
+}
+
 func (r *Router) numInflightItems() int {
 	return len(r.inflightRequests) + len(r.inflightBackgroundTasks)
 }
@@ -147,13 +170,6 @@ func (r *Router) onRequestFinished(id jsonrpc2.ID) {
 	r.opportunisticShutdown()
 }
 
-// caller must hold inflightLock
-func (r *Router) generateBackgroundTaskID() BackgroundTaskID {
-	id := r.backgroundTaskIDSeed
-	r.backgroundTaskIDSeed += 1
-	return id
-}
-
 // caller must hold inflightLock
 func (r *Router) onBackgroundTaskQueued(id BackgroundTaskID, task InFlightBackgroundTask) {
 	r.inflightBackgroundTasks[id] = task
@@ -163,7 +179,7 @@ func (r *Router) onBackgroundTaskQueued(id BackgroundTaskID, task InFlightBackgr
 func (r *Router) onBackgroundTaskFinished(id BackgroundTaskID) {
 	delete(r.inflightBackgroundTasks, id)
 	if r.shuttingDown {
-		r.globalConsumer.Infof("While shutting down, task %d has completed", id)
+		r.globalConsumer.Infof("While shutting down, task %s has completed", id)
 	}
 	r.opportunisticShutdown()
 }
@@ -205,7 +221,9 @@ func (r *Router) RegisterNotification(method string, nh NotificationHandler) {
 var _ jsonrpc2.Handler = (*Router)(nil)
 
 func (r *Router) HandleNotification(conn jsonrpc2.Conn, notif jsonrpc2.Notification) {
-	// muffin.
+	if nh, ok := r.NotificationHandlers[notif.Method]; ok {
+		r.wrapNotificationHandler(notif.Method, nh)(notif)
+	}
 }
 
 func (r *Router) HandleRequest(conn jsonrpc2.Conn, req jsonrpc2.Request) (interface{}, error) {
@@ -260,55 +278,24 @@ func (r *Router) HandleRequest(conn jsonrpc2.Conn, req jsonrpc2.Request) (interf
 
 			method: method,
 
-			QueueBackgroundTask: r.QueueBackgroundTask,
+			QueueBackgroundTask:  r.QueueBackgroundTask,
+			CancelBackgroundTask: r.CancelBackgroundTask,
+
+			events: r.events,
 		}
 
 		{
 			if h, ok := r.Handlers[method]; ok {
-				rc.Consumer.OnProgress = func(alpha float64) {
-					if rc.tracker == nil {
-						// skip
-						return
-					}
+				rc.bindProgress()
 
-					rc.tracker.SetProgress(alpha)
-					notif := ProgressNotification{
-						Progress: alpha,
-					}
-					stats := rc.tracker.Stats()
-					if stats != nil {
-						if stats.TimeLeft() != nil {
-							notif.ETA = stats.TimeLeft().Seconds()
-						}
-						if stats.BPS() != nil {
-							notif.BPS = stats.BPS().Value
-						} else {
-							notif.BPS = timeout.GetBPS()
-						}
-					}
-					// cannot use autogenerated wrappers to avoid import cycles
-					rc.Notify("Progress", notif)
-				}
-				rc.Consumer.OnProgressLabel = func(label string) {
-					// muffin
-				}
-				rc.Consumer.OnPauseProgress = func() {
-					if rc.tracker != nil {
-						rc.tracker.Pause()
-					}
-				}
-				rc.Consumer.OnResumeProgress = func() {
-					if rc.tracker != nil {
-						rc.tracker.Resume()
-					}
-				}
-
-				res, err = h(rc)
+				res, err = r.wrapRequestHandler(method, h)(rc)
 			} else {
-				err = &RpcError{
-					Code:    jsonrpc2.CodeMethodNotFound,
-					Message: fmt.Sprintf("Method '%s' not found", req.Method),
-				}
+				res, err = r.wrapRequestHandler(method, func(rc *RequestContext) (interface{}, error) {
+					return nil, &RpcError{
+						Code:    jsonrpc2.CodeMethodNotFound,
+						Message: fmt.Sprintf("Method '%s' not found", req.Method),
+					}
+				})(rc)
 			}
 		}
 		return
@@ -318,10 +305,25 @@ func (r *Router) HandleRequest(conn jsonrpc2.Conn, req jsonrpc2.Request) (interf
 		return res, nil
 	}
 
-	var code int64
-	var message string
-	var data map[string]interface{}
+	code, message, data := classifyError(err)
+	data["stack"] = fmt.Sprintf("%+v", err)
+	data["butlerVersion"] = buildinfo.VersionString
+
+	var rpcErr = &jsonrpc2.Error{
+		Code:    code,
+		Message: message,
+		Data:    nil,
+	}
+	err = rpcErr.SetData(data)
+	if err != nil {
+		return nil, err
+	}
+	return nil, rpcErr
+}
 
+// classifyError determines which JSON-RPC 2.0 error code, message and
+// data an error returned by a request handler should be reported with.
+func classifyError(err error) (code int64, message string, data map[string]interface{}) {
 	if ee, ok := AsButlerdError(err); ok {
 		code = ee.RpcErrorCode()
 		message = ee.RpcErrorMessage()
@@ -342,81 +344,12 @@ func (r *Router) HandleRequest(conn jsonrpc2.Conn, req jsonrpc2.Request) (interf
 	if data == nil {
 		data = make(map[string]interface{})
 	}
-	data["stack"] = fmt.Sprintf("%+v", err)
-	data["butlerVersion"] = buildinfo.VersionString
 
 	if ae, ok := itchio.AsAPIError(err); ok {
 		code = int64(CodeAPIError)
 		data["apiError"] = ae
 	}
-
-	var rpcErr = &jsonrpc2.Error{
-		Code:    code,
-		Message: message,
-		Data:    nil,
-	}
-	err = rpcErr.SetData(data)
-	if err != nil {
-		return nil, err
-	}
-	return nil, rpcErr
-}
-
-func (r *Router) doBackgroundTask(id BackgroundTaskID, bt BackgroundTask) {
-	defer func() {
-		router := r
-		if r := recover(); r != nil {
-			router.Logf("background task panicked: %+v", r)
-		}
-	}()
-
-	defer func() {
-		r.inflightLock.Lock()
-		r.onBackgroundTaskFinished(id)
-		r.inflightLock.Unlock()
-	}()
-
-	consumer := r.globalConsumer
-	rc := &RequestContext{
-		Ctx:         r.backgroundContext,
-		Consumer:    consumer,
-		Params:      nil,
-		Conn:        nil,
-		CancelFuncs: r.CancelFuncs,
-		dbPool:      r.dbPool,
-		Client:      r.getClient,
-
-		HTTPClient:    r.httpClient,
-		HTTPTransport: r.httpTransport,
-
-		Group:    r.Group,
-		Shutdown: r.initiateShutdown,
-
-		method: "",
-
-		QueueBackgroundTask: r.QueueBackgroundTask,
-	}
-
-	err := func() (retErr error) {
-		defer horror.RecoverInto(&retErr)
-		consumer.Debugf("Executing background task %d: %s", id, bt.Desc)
-		return bt.Do(rc)
-	}()
-	if err != nil {
-		consumer.Warnf("Background task error: %+v", err)
-	}
-}
-
-func (r *Router) QueueBackgroundTask(bt BackgroundTask) {
-	r.inflightLock.Lock()
-	id := r.generateBackgroundTaskID()
-	r.onBackgroundTaskQueued(id, InFlightBackgroundTask{
-		QueuedAt: time.Now().UTC(),
-		Desc:     fmt.Sprintf("[task %d] %s", id, bt.Desc),
-	})
-	r.inflightLock.Unlock()
-
-	go r.doBackgroundTask(id, bt)
+	return
 }
 
 func (r *Router) Logf(format string, args ...interface{}) {
@@ -426,14 +359,19 @@ func (r *Router) Logf(format string, args ...interface{}) {
 type BackgroundTaskFunc func(rc *RequestContext) error
 
 type RequestContext struct {
-	Ctx                 context.Context
-	Consumer            *state.Consumer
-	Client              GetClientFunc
-	QueueBackgroundTask func(bt BackgroundTask)
+	Ctx                  context.Context
+	Consumer             *state.Consumer
+	Client               GetClientFunc
+	QueueBackgroundTask  func(bt BackgroundTask)
+	CancelBackgroundTask func(id BackgroundTaskID) bool
 
 	HTTPClient    *http.Client
 	HTTPTransport *http.Transport
 
+	// If non-nil, files downloaded while handling this request
+	// are throttled by it, see Downloads.Drive
+	Throttle *bandwidth.Throttle
+
 	Params      *json.RawMessage
 	Conn        jsonrpc2.Conn
 	CancelFuncs *CancelFuncs
@@ -445,6 +383,9 @@ type RequestContext struct {
 	notificationInterceptors map[string]NotificationInterceptor
 	tracker                  tracker.Tracker
 
+	events     *eventHub
+	eventScope EventScope
+
 	method string
 }
 
@@ -473,10 +414,101 @@ func (rc *RequestContext) StopInterceptingNotification(method string) {
 func (rc *RequestContext) Notify(method string, params interface{}) error {
 	if rc.notificationInterceptors != nil {
 		if ni, ok := rc.notificationInterceptors[method]; ok {
+			// the origin connection never sees intercepted notifications,
+			// but subscribers (including its own) still should
+			rc.publishEvent(nil, method, params)
 			return ni(method, params)
 		}
 	}
-	return rc.Conn.Notify(method, params)
+	err := rc.Conn.Notify(method, params)
+	rc.publishEvent(rc.Conn, method, params)
+	return err
+}
+
+func (rc *RequestContext) publishEvent(origin jsonrpc2.Conn, method string, params interface{}) {
+	if rc.events != nil && isEventMethod(method) {
+		rc.events.publish(origin, method, params, rc.eventScope)
+	}
+}
+
+// Fork returns a copy of the request context with its own progress
+// tracker, notification interceptors and event scope, so that several
+// operations can run concurrently on behalf of the same request.
+func (rc *RequestContext) Fork() *RequestContext {
+	child := &RequestContext{
+		Ctx:                  rc.Ctx,
+		Client:               rc.Client,
+		QueueBackgroundTask:  rc.QueueBackgroundTask,
+		CancelBackgroundTask: rc.CancelBackgroundTask,
+
+		HTTPClient:    rc.HTTPClient,
+		HTTPTransport: rc.HTTPTransport,
+		Throttle:      rc.Throttle,
+
+		Params:      rc.Params,
+		Conn:        rc.Conn,
+		CancelFuncs: rc.CancelFuncs,
+		dbPool:      rc.dbPool,
+
+		Group:    rc.Group,
+		Shutdown: rc.Shutdown,
+
+		events:     rc.events,
+		eventScope: rc.eventScope,
+
+		method: rc.method,
+	}
+
+	if rc.Consumer != nil {
+		consumer := *rc.Consumer
+		child.Consumer = &consumer
+		if rc.Consumer.OnProgress != nil {
+			child.bindProgress()
+		}
+	}
+	return child
+}
+
+// bindProgress makes the consumer's progress updates go to rc's tracker
+// and notify the peer.
+func (rc *RequestContext) bindProgress() {
+	rc.Consumer.OnProgress = func(alpha float64) {
+		if rc.tracker == nil {
+			// skip
+			return
+		}
+
+		rc.tracker.SetProgress(alpha)
+		notif := ProgressNotification{
+			Progress: alpha,
+		}
+		stats := rc.tracker.Stats()
+		if stats != nil {
+			if stats.TimeLeft() != nil {
+				notif.ETA = stats.TimeLeft().Seconds()
+			}
+			if stats.BPS() != nil {
+				notif.BPS = stats.BPS().Value
+			} else {
+				notif.BPS = timeout.GetBPS()
+			}
+		}
+		// cannot use autogenerated wrappers to avoid import cycles
+		rc.Notify("Progress", notif)
+	}
+	rc.Consumer.OnProgressLabel = func(label string) {
+		// muffin
+	}
+	rc.Consumer.OnPauseProgress = func() {
+		if rc.tracker != nil {
+			rc.tracker.Pause()
+		}
+	}
+	rc.Consumer.OnResumeProgress = func() {
+		if rc.tracker != nil {
+			rc.tracker.Resume()
+		}
+	}
 }
 
 func (rc *RequestContext) RootClient() *itchio.Client {
@@ -537,7 +569,9 @@ func (rc *RequestContext) EndProgress() {
 func (rc *RequestContext) GetConn() *sqlite.Conn {
 	getCtx, cancel := context.WithTimeout(rc.Ctx, 3*time.Second)
 	defer cancel()
+	startTime := time.Now()
 	conn := rc.dbPool.Get(getCtx)
+	dbConnWaitSeconds.Observe(time.Since(startTime).Seconds())
 	if conn == nil {
 		panic(errors.WithStack(CodeDatabaseBusy))
 	}
@@ -1,7 +1,45 @@
 package butlerd
 
-import "github.com/itchio/butler/manager"
+import (
+	"encoding/json"
+
+	"crawshaw.io/sqlite"
+	"github.com/itchio/butler/database/models"
+	"github.com/itchio/butler/manager"
+	"github.com/pkg/errors"
+)
 
 func (rc *RequestContext) HostEnumerator() manager.HostEnumerator {
-	return manager.DefaultHostEnumerator()
+	var custom manager.Hosts
+	rc.WithConn(func(conn *sqlite.Conn) {
+		for _, ch := range models.AllCustomHosts(conn) {
+			host, err := DecodeCustomHost(ch)
+			if err != nil {
+				rc.Consumer.Warnf("Ignoring custom host (%s): %v", ch.ID, err)
+				continue
+			}
+			custom = append(custom, host)
+		}
+	})
+	return manager.CustomHostEnumerator(custom)
+}
+
+// DecodeCustomHost returns the host a custom host was added with
+func DecodeCustomHost(ch *models.CustomHost) (manager.Host, error) {
+	var host manager.Host
+	err := json.Unmarshal([]byte(ch.Host), &host)
+	if err != nil {
+		return host, errors.Wrap(err, "unmarshalling custom host")
+	}
+	return host, nil
+}
+
+// EncodeCustomHost sets the host a custom host is stored with
+func EncodeCustomHost(ch *models.CustomHost, host manager.Host) error {
+	contents, err := json.Marshal(host)
+	if err != nil {
+		return errors.Wrap(err, "marshalling custom host")
+	}
+	ch.Host = models.JSON(contents)
+	return nil
 }
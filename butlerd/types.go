@@ -1,10 +1,15 @@
 package butlerd
 
 import (
+	"regexp"
 	"time"
 
+	"github.com/helloeave/json"
+	"github.com/itchio/butler/butlerd/generous/spec"
+	"github.com/itchio/butler/manager"
 	"github.com/itchio/hush"
 	"github.com/itchio/hush/manifest"
+	"github.com/itchio/ox"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 	itchio "github.com/itchio/go-itchio"
@@ -66,6 +71,31 @@ func (p MetaShutdownParams) Validate() error {
 type MetaShutdownResult struct {
 }
 
+// Returns the API spec of the butlerd instance the client is
+// connected to, along with the list of requests it can handle.
+//
+// Clients can use it to detect which features are available,
+// instead of running into `Method not found` errors at runtime.
+//
+// @name Meta.Introspect
+// @category Utilities
+// @caller client
+type MetaIntrospectParams struct {
+}
+
+func (p MetaIntrospectParams) Validate() error {
+	return nil
+}
+
+type MetaIntrospectResult struct {
+	// The full API spec, as generated from butler's sources
+	Spec *spec.Spec `json:"spec"`
+
+	// Names of all the requests this instance has handlers for,
+	// like `Version.Get`, sorted alphabetically.
+	Methods []string `json:"methods"`
+}
+
 // The first notification sent when @@MetaFlowParams is called.
 //
 // @category Utilities
@@ -134,6 +164,36 @@ func (p NetworkSetBandwidthThrottleParams) Validate() error {
 
 type NetworkSetBandwidthThrottleResult struct{}
 
+// Retrieve the network status, as determined by probing a URL
+// whenever a network failure happens, see the `--probe-url`
+// daemon option.
+//
+// @name Network.GetStatus
+// @category Utilities
+// @caller client
+type NetworkGetStatusParams struct{}
+
+func (p NetworkGetStatusParams) Validate() error {
+	return nil
+}
+
+type NetworkGetStatusResult struct {
+	Status NetworkStatus `json:"status"`
+	// The URL being probed
+	ProbeURL string `json:"probeUrl"`
+	// Whether offline mode is being simulated, see @@NetworkSetSimulateOfflineParams
+	SimulateOffline bool `json:"simulateOffline"`
+	// Number of probes that failed in a row
+	FailedProbes int64 `json:"failedProbes"`
+	// @optional
+	LastProbeAt *time.Time `json:"lastProbeAt"`
+	// Set while waiting to probe again
+	// @optional
+	NextProbeAt *time.Time `json:"nextProbeAt"`
+	// @optional
+	LastError string `json:"lastError"`
+}
+
 //----------------------------------------------------------------------
 // Profile
 //----------------------------------------------------------------------
@@ -1229,6 +1289,9 @@ type Cave struct {
 	Stats *CaveStats `json:"stats"`
 	// Information about where the cave is installed, how much space it takes up etc.
 	InstallInfo *CaveInstallInfo `json:"installInfo"`
+	// Set with @@CavesSetLaunchOverridesParams
+	// @optional
+	LaunchOverrides *LaunchOverrides `json:"launchOverrides,omitempty"`
 }
 
 // CaveStats contains stats about cave usage and first install
@@ -1261,6 +1324,8 @@ type InstallLocationSummary struct {
 	Path string `json:"path"`
 	// Information about the size used and available at this install location
 	SizeInfo *InstallLocationSizeInfo `json:"sizeInfo,omitempty"`
+	// True if installs to this location are verified once they're done
+	VerifyInstalls bool `json:"verifyInstalls"`
 }
 
 type InstallLocationSizeInfo struct {
@@ -1555,6 +1620,79 @@ func (p CavesSetPinnedParams) Validate() error {
 
 type CavesSetPinnedResult struct{}
 
+// Set how a cave is launched by @@LaunchParams, for example to always
+// pass `-windowed`, or to always run it under wine.
+//
+// @name Caves.SetLaunchOverrides
+// @category Install
+// @caller client
+type CavesSetLaunchOverridesParams struct {
+	// ID of the cave to set launch overrides for
+	CaveID string `json:"caveId"`
+
+	// Replace the cave's launch overrides, clears them if not set
+	// @optional
+	Overrides *LaunchOverrides `json:"overrides"`
+}
+
+func (p CavesSetLaunchOverridesParams) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.CaveID, validation.Required),
+	)
+}
+
+type CavesSetLaunchOverridesResult struct{}
+
+// LaunchOverrides are merged into what @@LaunchParams gets from the
+// manifest and the hosts it finds.
+type LaunchOverrides struct {
+	// Passed after the arguments from the manifest action
+	// @optional
+	Args []string `json:"args,omitempty"`
+
+	// Additional environment variables, like `DXVK_HUD=1`
+	// @optional
+	Env map[string]string `json:"env,omitempty"`
+
+	// Working directory of the game. If relative, it's relative to
+	// the install folder.
+	// @optional
+	WorkingDirectory string `json:"workingDirectory,omitempty"`
+
+	// If set, only launch targets for hosts with this runtime are
+	// considered, unless there are none
+	// @optional
+	PreferredRuntime *ox.Runtime `json:"preferredRuntime,omitempty"`
+
+	// If set, only launch targets for hosts using this wrapper binary
+	// (like "wine") are considered, unless there are none. Use "native"
+	// to prefer hosts without a wrapper.
+	// @optional
+	PreferredWrapper string `json:"preferredWrapper,omitempty"`
+
+	// If true, the launch target picked with @@PickManifestActionParams
+	// is saved in ActionIndex, ActionName and ActionHost, so it's not
+	// asked again.
+	// @optional
+	RememberAction bool `json:"rememberAction,omitempty"`
+
+	// Index of the launch target to use when there's more than one,
+	// instead of asking with @@PickManifestActionParams. Ignored if
+	// out of range, or if the target at that index no longer has
+	// ActionName and ActionHost, as happens when the game is updated.
+	// @optional
+	ActionIndex *int64 `json:"actionIndex,omitempty"`
+
+	// Name of the manifest action at ActionIndex
+	// @optional
+	ActionName string `json:"actionName,omitempty"`
+
+	// Host of the launch target at ActionIndex, like
+	// `64-bit Windows (wrapper=wine)`
+	// @optional
+	ActionHost string `json:"actionHost,omitempty"`
+}
+
 // Create a shortcut for an existing cave .
 //
 // @name Install.CreateShortcut
@@ -1745,6 +1883,8 @@ type TaskReason string
 const (
 	// Task was started for an install operation
 	TaskReasonInstall TaskReason = "install"
+	// Task was started for an update operation
+	TaskReasonUpdate TaskReason = "update"
 	// Task was started for an uninstall operation
 	TaskReasonUninstall TaskReason = "uninstall"
 )
@@ -1763,6 +1903,8 @@ const (
 	TaskTypeUpdate TaskType = "update"
 	// We're healing from a signature and heal source
 	TaskTypeHeal TaskType = "heal"
+	// We're checking an install against its build's signature
+	TaskTypeVerify TaskType = "verify"
 )
 
 // Each operation is made up of one or more tasks. This notification
@@ -1811,6 +1953,26 @@ type InstallResult struct {
 	// TODO: verdict ?
 }
 
+// Sent during @@OperationStartParams when verifying an install finds
+// files that don't match the build's signature, see
+// @@InstallLocationsSetVerifyInstallsParams. They're healed right after.
+//
+// @name Install.Wounds
+// @category Install
+type InstallWoundsNotification struct {
+	// The game that was verified
+	Game *itchio.Game `json:"game"`
+	// The upload that was verified
+	Upload *itchio.Upload `json:"upload"`
+	// The build that was verified
+	Build *itchio.Build `json:"build"`
+	// Paths of the files, folders and symlinks that are missing
+	// or corrupted, relative to the install folder
+	Paths []string `json:"paths"`
+	// Number of corrupted bytes
+	TotalCorrupted int64 `json:"totalCorrupted"`
+}
+
 // @name Install.Locations.List
 // @category Install
 // @caller client
@@ -1836,6 +1998,11 @@ type InstallLocationsAddParams struct {
 
 	// path of the new install location
 	Path string `json:"path"`
+
+	// if true, installs to this location are verified once
+	// they're done, see @@InstallLocationsSetVerifyInstallsParams
+	// @optional
+	VerifyInstalls bool `json:"verifyInstalls"`
 }
 
 func (p InstallLocationsAddParams) Validate() error {
@@ -1848,6 +2015,35 @@ type InstallLocationsAddResult struct {
 	InstallLocation *InstallLocationSummary `json:"installLocation"`
 }
 
+// Sets whether installs to an install location are verified once
+// they're done, by checking the install folder against the build's
+// signature. Anything missing or corrupted is reported via
+// @@InstallWoundsNotification, then healed.
+//
+// This only applies to wharf-enabled uploads. butler can also verify
+// installs to all locations, with `butler daemon --verify-installs`.
+//
+// @name Install.Locations.SetVerifyInstalls
+// @category Install
+// @caller client
+type InstallLocationsSetVerifyInstallsParams struct {
+	// identifier of the install location
+	ID string `json:"id"`
+
+	// whether to verify installs
+	Enabled bool `json:"enabled"`
+}
+
+func (p InstallLocationsSetVerifyInstallsParams) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.ID, validation.Required),
+	)
+}
+
+type InstallLocationsSetVerifyInstallsResult struct {
+	InstallLocation *InstallLocationSummary `json:"installLocation"`
+}
+
 // @name Install.Locations.Remove
 // @category Install
 // @caller client
@@ -1937,6 +2133,10 @@ type InstallLocationsScanResult struct {
 // Queue a download that will be performed later by
 // @@DownloadsDriveParams.
 //
+// The disk space the install needs is reserved right away, so this
+// fails with an insufficient disk space error if other downloads already
+// reserved too much of the install location.
+//
 // @name Downloads.Queue
 // @category Downloads
 // @caller client
@@ -2002,16 +2202,55 @@ func (p DownloadsClearFinishedParams) Validate() error {
 type DownloadsClearFinishedResult struct {
 }
 
-// Drive downloads, which is: perform them one at a time,
+// Drive downloads, which is: perform them, by order of priority,
 // until they're all finished.
 //
 // @name Downloads.Drive
 // @category Downloads
 // @caller client
-type DownloadsDriveParams struct{}
+type DownloadsDriveParams struct {
+	// How many downloads to perform at the same time. Defaults to 1.
+	// When a download is prioritized, the lowest-priority one being
+	// performed is stopped to make room for it.
+	// @optional
+	MaxConcurrent int64 `json:"maxConcurrent"`
+
+	// How downloads that failed because of a transient error (network
+	// errors, server errors, timeouts) are retried. Other errors fail
+	// the download right away.
+	// @optional
+	RetryPolicy *DownloadRetryPolicy `json:"retryPolicy"`
+}
 
 func (p DownloadsDriveParams) Validate() error {
-	return nil
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.MaxConcurrent, validation.Min(0)),
+		validation.Field(&p.RetryPolicy),
+	)
+}
+
+type DownloadRetryPolicy struct {
+	// How many times a download is attempted before giving up,
+	// including the first attempt. Defaults to 5.
+	// @optional
+	MaxAttempts int64 `json:"maxAttempts"`
+
+	// Delay before the first retry, in seconds, doubled for each
+	// retry after that. Defaults to 10.
+	// @optional
+	InitialDelay float64 `json:"initialDelay"`
+
+	// Max delay between retries, in seconds. Defaults to 300.
+	// @optional
+	MaxDelay float64 `json:"maxDelay"`
+}
+
+func (rp DownloadRetryPolicy) Validate() error {
+	return validation.ValidateStruct(&rp,
+		validation.Field(&rp.MaxAttempts, validation.Min(0)),
+		validation.Field(&rp.InitialDelay, validation.Min(0.0)),
+		validation.Field(&rp.MaxDelay, validation.Min(0.0)),
+	)
 }
 
 type DownloadsDriveResult struct{}
@@ -2074,8 +2313,12 @@ type DownloadsDriveNetworkStatusNotification struct {
 type NetworkStatus string
 
 const (
-	NetworkStatusOnline  NetworkStatus = "online"
+	NetworkStatusOnline NetworkStatus = "online"
+	// A network failure happened, butler is probing until it's online again
 	NetworkStatusOffline NetworkStatus = "offline"
+	// Probing failed for too long and was given up on, it'll start again
+	// the next time something needs the network
+	NetworkStatusUnreachable NetworkStatus = "unreachable"
 )
 
 type DownloadReason string
@@ -2103,6 +2346,17 @@ type Download struct {
 	StartedAt     *time.Time     `json:"startedAt"`
 	FinishedAt    *time.Time     `json:"finishedAt"`
 	StagingFolder string         `json:"stagingFolder"`
+	// Paused downloads are skipped by @@DownloadsDriveParams until resumed
+	Paused bool `json:"paused"`
+	// Number of attempts that failed with a transient error, see
+	// @@DownloadRetryPolicy
+	Attempts int64 `json:"attempts"`
+	// When the download will be attempted again, if it's waiting
+	// to be retried. errorCode is then the code of the last failed
+	// attempt, but error is only set once the download has failed
+	// for good.
+	// @optional
+	NextAttemptAt *time.Time `json:"nextAttemptAt"`
 }
 
 type DownloadProgress struct {
@@ -2146,6 +2400,290 @@ func (p DownloadsDiscardParams) Validate() error {
 
 type DownloadsDiscardResult struct{}
 
+// Pause a download: it stops being performed, but unlike
+// @@DownloadsDiscardParams, its staging folder is kept, so it
+// can pick up where it left off once resumed.
+//
+// @name Downloads.Pause
+// @category Downloads
+// @caller client
+type DownloadsPauseParams struct {
+	DownloadID string `json:"downloadId"`
+}
+
+func (p DownloadsPauseParams) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.DownloadID, validation.Required),
+	)
+}
+
+type DownloadsPauseResult struct{}
+
+// Resume a download that was paused with @@DownloadsPauseParams.
+//
+// @name Downloads.Resume
+// @category Downloads
+// @caller client
+type DownloadsResumeParams struct {
+	DownloadID string `json:"downloadId"`
+}
+
+func (p DownloadsResumeParams) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.DownloadID, validation.Required),
+	)
+}
+
+type DownloadsResumeResult struct{}
+
+// Retrieve the download schedule, see @@DownloadsSetScheduleParams.
+//
+// @name Downloads.GetSchedule
+// @category Downloads
+// @caller client
+type DownloadsGetScheduleParams struct{}
+
+func (p DownloadsGetScheduleParams) Validate() error {
+	return nil
+}
+
+type DownloadsGetScheduleResult struct {
+	Schedule *DownloadSchedule `json:"schedule"`
+}
+
+// Set when, and how fast, @@DownloadsDriveParams performs downloads.
+// The schedule is persisted, and replaces any previous one.
+//
+// Rate limits apply to all of butler's network traffic, and add up
+// with @@NetworkSetBandwidthThrottleParams: the strictest one wins.
+//
+// @name Downloads.SetSchedule
+// @category Downloads
+// @caller client
+type DownloadsSetScheduleParams struct {
+	Schedule *DownloadSchedule `json:"schedule"`
+}
+
+func (p DownloadsSetScheduleParams) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Schedule, validation.Required),
+	)
+}
+
+type DownloadsSetScheduleResult struct{}
+
+type DownloadSchedule struct {
+	// Time-of-day windows, in local time. When windows overlap, the
+	// first one wins.
+	Windows []*DownloadWindow `json:"windows"`
+
+	// Max rate in kbps outside of windows, 0 means unlimited.
+	// @optional
+	DefaultMaxRate int64 `json:"defaultMaxRate"`
+
+	// Max rate in kbps for each download with reason `update`, 0 means no
+	// separate limit. Other downloads aren't slowed down by it.
+	// @optional
+	UpdateMaxRate int64 `json:"updateMaxRate"`
+
+	// If true, downloads with reason `update` are only performed
+	// during windows, and stopped (not discarded) when windows end.
+	// @optional
+	UpdatesOnlyInWindows bool `json:"updatesOnlyInWindows"`
+}
+
+func (s DownloadSchedule) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Windows),
+		validation.Field(&s.DefaultMaxRate, validation.Min(0)),
+		validation.Field(&s.UpdateMaxRate, validation.Min(0)),
+	)
+}
+
+type DownloadWindow struct {
+	// Start of the window, as HH:MM in 24-hour local time
+	Start string `json:"start"`
+	// End of the window, as HH:MM in 24-hour local time. If it's before
+	// start, the window spans midnight.
+	End string `json:"end"`
+	// Max rate in kbps during the window, 0 means unlimited.
+	// @optional
+	MaxRate int64 `json:"maxRate"`
+}
+
+var timeOfDayRegexp = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+func (w DownloadWindow) Validate() error {
+	return validation.ValidateStruct(&w,
+		validation.Field(&w.Start, validation.Required, validation.Match(timeOfDayRegexp)),
+		validation.Field(&w.End, validation.Required, validation.Match(timeOfDayRegexp)),
+		validation.Field(&w.MaxRate, validation.Min(0)),
+	)
+}
+
+// List downloads performed by @@DownloadsDriveParams, most recent
+// first, whether they succeeded or failed.
+//
+// @name Downloads.History
+// @category Downloads
+// @caller client
+type DownloadsHistoryParams struct {
+	// If set, only lists downloads for this cave
+	// @optional
+	CaveID string `json:"caveId"`
+
+	// If set, only lists downloads for this game
+	// @optional
+	GameID int64 `json:"gameId"`
+
+	// Maximum number of items to return at a time.
+	// @optional
+	Limit int64 `json:"limit"`
+
+	// If true, lists oldest downloads first
+	// @optional
+	Reverse bool `json:"reverse"`
+
+	// Used for pagination, if specified
+	// @optional
+	Cursor Cursor `json:"cursor"`
+}
+
+func (p DownloadsHistoryParams) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Limit, validation.Min(0)),
+	)
+}
+
+func (p DownloadsHistoryParams) GetCursor() Cursor {
+	return p.Cursor
+}
+
+func (p DownloadsHistoryParams) GetLimit() int64 {
+	return p.Limit
+}
+
+type DownloadsHistoryResult struct {
+	Items []*DownloadHistoryEntry `json:"items"`
+
+	// Use to fetch the next 'page' of results
+	// @optional
+	NextCursor Cursor `json:"nextCursor,omitempty"`
+}
+
+type DownloadHistoryEntry struct {
+	ID string `json:"id"`
+	// The download this entry is about. It may have been cleared since.
+	DownloadID string         `json:"downloadId"`
+	CaveID     string         `json:"caveId"`
+	Game       *itchio.Game   `json:"game"`
+	Upload     *itchio.Upload `json:"upload"`
+	// @optional
+	Build  *itchio.Build  `json:"build"`
+	Reason DownloadReason `json:"reason"`
+	// "install", "upgrade", "heal", or "none" if the download failed
+	// before picking one
+	Strategy   string    `json:"strategy"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	// Wall time, in seconds
+	WallTime float64 `json:"wallTime"`
+	// Estimated from speed samples, in bytes
+	BytesTransferred int64 `json:"bytesTransferred"`
+	// Average speed, in bytes per second
+	AverageBPS float64 `json:"averageBps"`
+	// Last speed samples, as sent in @@DownloadsDriveProgressNotification
+	SpeedHistory []float64 `json:"speedHistory"`
+	// Standard butlerd error code, set if the download failed
+	// @optional
+	ErrorCode *int64 `json:"errorCode"`
+	// Short error message, set if the download failed
+	// @optional
+	ErrorMessage *string `json:"errorMessage"`
+}
+
+// Writes pending downloads (not finished and not discarded) to a
+// @@DownloadManifest file, which can be queued again with @@DownloadsImportParams,
+// on this machine or another.
+//
+// @name Downloads.Export
+// @category Downloads
+// @caller client
+type DownloadsExportParams struct {
+	// Where to write the manifest. Overwritten if it exists.
+	Path string `json:"path"`
+}
+
+func (p DownloadsExportParams) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Path, validation.Required),
+	)
+}
+
+type DownloadsExportResult struct {
+	// What was written to the manifest
+	Manifest *DownloadManifest `json:"manifest"`
+}
+
+// Queues the downloads listed in a @@DownloadManifest file, as written by
+// @@DownloadsExportParams. Games, uploads and builds are fetched again
+// from the API, then queued via @@InstallQueueParams.
+//
+// Entries that can't be queued are skipped and listed in the result.
+//
+// @name Downloads.Import
+// @category Downloads
+// @caller client
+type DownloadsImportParams struct {
+	// Manifest to read
+	Path string `json:"path"`
+
+	// If set, entries are queued to this install location
+	// instead of the one they list. Install location IDs are
+	// different on every machine.
+	// @optional
+	InstallLocationID string `json:"installLocationId"`
+}
+
+func (p DownloadsImportParams) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Path, validation.Required),
+	)
+}
+
+type DownloadsImportResult struct {
+	// Downloads that were queued
+	Items []*InstallQueueResult `json:"items"`
+	// Entries that couldn't be queued
+	Failed []*DownloadImportFailure `json:"failed"`
+}
+
+type DownloadImportFailure struct {
+	Entry *DownloadManifestEntry `json:"entry"`
+	// Short error message
+	Error string `json:"error"`
+}
+
+// A list of downloads that can be queued with @@DownloadsImportParams
+type DownloadManifest struct {
+	// Currently always 1
+	Version int64                    `json:"version"`
+	Entries []*DownloadManifestEntry `json:"entries"`
+}
+
+type DownloadManifestEntry struct {
+	GameID   int64 `json:"gameId"`
+	UploadID int64 `json:"uploadId"`
+	// If not set, the latest build of the upload is used
+	// @optional
+	BuildID int64          `json:"buildId,omitempty"`
+	Reason  DownloadReason `json:"reason"`
+	// @optional
+	CaveID string `json:"caveId,omitempty"`
+	// @optional
+	InstallLocationID string `json:"installLocationId,omitempty"`
+}
+
 //----------------------------------------------------------------------
 // CheckUpdate
 //----------------------------------------------------------------------
@@ -2271,12 +2809,27 @@ type LaunchParams struct {
 	// Enable sandbox (regardless of manifest opt-in)
 	// @optional
 	Sandbox bool `json:"sandbox,omitempty"`
+
+	// Which sandbox to use on Linux, if the sandbox is enabled
+	// (with Sandbox or by manifest opt-in). Defaults to firejail.
+	// @optional
+	SandboxBackend SandboxBackend `json:"sandboxBackend,omitempty"`
+
+	// Deny network access to sandboxed games. Only supported by
+	// the bubblewrap backend.
+	// @optional
+	SandboxNoNetwork bool `json:"sandboxNoNetwork,omitempty"`
 }
 
 func (p LaunchParams) Validate() error {
 	return validation.ValidateStruct(&p,
 		validation.Field(&p.CaveID, validation.Required),
 		validation.Field(&p.PrereqsDir, validation.Required),
+		validation.Field(&p.SandboxBackend, validation.In(
+			SandboxBackendAuto,
+			SandboxBackendBwrap,
+			SandboxBackendFirejail,
+		)),
 	)
 }
 
@@ -2294,6 +2847,88 @@ type LaunchRunningNotification struct{}
 // @category Launch
 type LaunchExitedNotification struct{}
 
+// List sessions recorded by @@LaunchParams, most recent first,
+// whether the game ran fine, crashed, or failed to start.
+//
+// @name Launch.Sessions.List
+// @category Launch
+// @caller client
+type LaunchSessionsListParams struct {
+	// If set, only lists sessions for this cave
+	// @optional
+	CaveID string `json:"caveId"`
+
+	// If set, only lists sessions for this game
+	// @optional
+	GameID int64 `json:"gameId"`
+
+	// Maximum number of items to return at a time.
+	// @optional
+	Limit int64 `json:"limit"`
+
+	// If true, lists oldest sessions first
+	// @optional
+	Reverse bool `json:"reverse"`
+
+	// Used for pagination, if specified
+	// @optional
+	Cursor Cursor `json:"cursor"`
+}
+
+func (p LaunchSessionsListParams) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Limit, validation.Min(0)),
+	)
+}
+
+func (p LaunchSessionsListParams) GetCursor() Cursor {
+	return p.Cursor
+}
+
+func (p LaunchSessionsListParams) GetLimit() int64 {
+	return p.Limit
+}
+
+type LaunchSessionsListResult struct {
+	Items []*LaunchSession `json:"items"`
+
+	// Use to fetch the next 'page' of results
+	// @optional
+	NextCursor Cursor `json:"nextCursor,omitempty"`
+}
+
+type LaunchSession struct {
+	ID string `json:"id"`
+	// The cave that was launched. It may have been uninstalled since.
+	CaveID string         `json:"caveId"`
+	Game   *itchio.Game   `json:"game"`
+	Upload *itchio.Upload `json:"upload"`
+	// @optional
+	Build *itchio.Build `json:"build"`
+	// When the game actually started running, or when the launch
+	// started if it never got that far
+	StartedAt time.Time `json:"startedAt"`
+	// Not set while the game is running, or if butler exited before it did
+	// @optional
+	EndedAt *time.Time `json:"endedAt"`
+	// Runtime of the host the game was launched on, like "linux-amd64"
+	Host string `json:"host"`
+	// Full path to the wrapper (wine, etc.), if any
+	// @optional
+	Wrapper  string         `json:"wrapper"`
+	Strategy LaunchStrategy `json:"strategy"`
+	// Only set for strategies that run a process, once it has exited
+	// @optional
+	ExitCode *int64 `json:"exitCode"`
+	// Last lines the game wrote to standard output
+	Stdout []string `json:"stdout"`
+	// Last lines the game wrote to standard error
+	Stderr []string `json:"stderr"`
+	// Short error message, set if the launch failed
+	// @optional
+	ErrorMessage *string `json:"errorMessage"`
+}
+
 // Sent during @@LaunchParams if the game/application comes with a service license
 // agreement.
 //
@@ -2575,6 +3210,87 @@ func (p CleanDownloadsApplyParams) Validate() error {
 // @category Clean Downloads
 type CleanDownloadsApplyResult struct{}
 
+//----------------------------------------------------------------------
+// Hosts
+//----------------------------------------------------------------------
+
+// List wrapper hosts added with @@HostsAddParams, oldest first.
+//
+// @name Hosts.List
+// @category Hosts
+// @caller client
+type HostsListParams struct{}
+
+func (p HostsListParams) Validate() error {
+	return nil
+}
+
+type HostsListResult struct {
+	Hosts []*CustomHost `json:"hosts"`
+}
+
+// Declare a wrapper host, like a Proton prefix for windows-amd64, or
+// box64 for linux-amd64 on arm64. Uploads for its runtime become
+// compatible, and its launch targets are considered by @@LaunchParams,
+// before the wrappers butler finds by itself (like wine).
+//
+// Hosts whose wrapper binary can't be found are skipped.
+//
+// @name Hosts.Add
+// @category Hosts
+// @caller client
+type HostsAddParams struct {
+	// Human-readable, like "Proton 8 (default prefix)"
+	// @optional
+	Name string `json:"name"`
+
+	// Runtime the wrapper can run, and how to run it
+	Host manager.Host `json:"host"`
+}
+
+func (p HostsAddParams) Validate() error {
+	host := p.Host
+	var wrapperBinary string
+	if host.Wrapper != nil {
+		wrapperBinary = host.Wrapper.WrapperBinary
+	}
+
+	return validation.Errors{
+		"host.runtime.platform":      validation.Validate(host.Runtime.Platform, validation.Required),
+		"host.wrapper.wrapperBinary": validation.Validate(wrapperBinary, validation.Required),
+		"host.remoteLaunchName":      validation.Validate(host.RemoteLaunchName, validation.In("").Error("can't be set for custom hosts")),
+	}.Filter()
+}
+
+type HostsAddResult struct {
+	Host *CustomHost `json:"host"`
+}
+
+// Remove a wrapper host added with @@HostsAddParams.
+//
+// @name Hosts.Remove
+// @category Hosts
+// @caller client
+type HostsRemoveParams struct {
+	ID string `json:"id"`
+}
+
+func (p HostsRemoveParams) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.ID, validation.Required),
+	)
+}
+
+type HostsRemoveResult struct{}
+
+type CustomHost struct {
+	ID string `json:"id"`
+	// Human-readable, like "Proton 8 (default prefix)"
+	Name      string       `json:"name"`
+	Host      manager.Host `json:"host"`
+	CreatedAt time.Time    `json:"createdAt"`
+}
+
 //----------------------------------------------------------------------
 // System
 //----------------------------------------------------------------------
@@ -2599,6 +3315,169 @@ type SystemStatFSResult struct {
 	TotalSize int64 `json:"totalSize"`
 }
 
+//----------------------------------------------------------------------
+// Events
+//----------------------------------------------------------------------
+
+// Subscribes to notifications sent by butlerd on other connections,
+// so that, for example, a tray icon can show the progress of a
+// @@DownloadsDriveParams call made by the main UI.
+//
+// Only the following notifications can be subscribed to:
+// `Downloads.Drive.*`, `TaskStarted`, `Progress`, `LaunchRunning`
+// and `LaunchExited`.
+//
+// Matching notifications are then sent as @@EventsEventNotification
+// until @@EventsUnsubscribeParams is called, or the connection is closed.
+// Subscribers that fall too far behind are unsubscribed, and sent
+// @@EventsDroppedNotification once the events queued so far are delivered.
+//
+// @name Events.Subscribe
+// @category Events
+// @caller client
+type EventsSubscribeParams struct {
+	// Methods of notifications to receive, like `Downloads.Drive.Progress`.
+	// If empty, all notifications that can be subscribed to are received.
+	// @optional
+	Methods []string `json:"methods"`
+
+	// If set, only receive notifications about these caves.
+	// @optional
+	CaveIDs []string `json:"caveIds"`
+
+	// If set, only receive notifications about these downloads.
+	// @optional
+	DownloadIDs []string `json:"downloadIds"`
+}
+
+func (p EventsSubscribeParams) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Methods, validation.Each(validation.In(EventMethodList...))),
+	)
+}
+
+type EventsSubscribeResult struct {
+	// Pass to @@EventsUnsubscribeParams to stop receiving events
+	SubscriptionID string `json:"subscriptionId"`
+}
+
+// Sent to a connection that called @@EventsSubscribeParams, whenever a
+// notification matching the subscription is sent on another connection.
+//
+// @name Events.Event
+// @category Events
+type EventsEventNotification struct {
+	SubscriptionID string `json:"subscriptionId"`
+
+	// Method of the original notification, like `Downloads.Drive.Progress`
+	Method string `json:"method"`
+	// Params of the original notification
+	Params json.RawMessage `json:"params"`
+
+	// The cave the original notification is about, if any
+	// @optional
+	CaveID string `json:"caveId,omitempty"`
+	// The download the original notification is about, if any
+	// @optional
+	DownloadID string `json:"downloadId,omitempty"`
+}
+
+// Sent to a connection that called @@EventsSubscribeParams, after the
+// last @@EventsEventNotification, if it couldn't keep up with events and
+// got unsubscribed. Events sent since then are missing, so call
+// @@EventsSubscribeParams again and refresh whatever state was tracked.
+//
+// @name Events.Dropped
+// @category Events
+type EventsDroppedNotification struct {
+	SubscriptionID string `json:"subscriptionId"`
+}
+
+// Stops sending events for a subscription made with @@EventsSubscribeParams.
+//
+// @name Events.Unsubscribe
+// @category Events
+// @caller client
+type EventsUnsubscribeParams struct {
+	SubscriptionID string `json:"subscriptionId"`
+}
+
+func (p EventsUnsubscribeParams) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.SubscriptionID, validation.Required),
+	)
+}
+
+type EventsUnsubscribeResult struct {
+	DidUnsubscribe bool `json:"didUnsubscribe"`
+}
+
+//----------------------------------------------------------------------
+// Tasks
+//----------------------------------------------------------------------
+
+// Lists background tasks: those that are pending, waiting to be
+// retried, or that failed too many times and were given up on.
+//
+// Background tasks are persisted, and resumed when butlerd starts.
+//
+// @name Tasks.List
+// @category Tasks
+// @caller client
+type TasksListParams struct{}
+
+func (p TasksListParams) Validate() error {
+	return nil
+}
+
+type TasksListResult struct {
+	Tasks []*Task `json:"tasks"`
+}
+
+// A background task, like syncing play time for a game.
+type Task struct {
+	// An UUID
+	ID string `json:"id"`
+	// Which kind of task it is, like `FetchUserGameSessions`
+	Kind string `json:"kind"`
+	// Human-readable description of the task
+	Desc string `json:"desc"`
+
+	// Number of failed attempts so far
+	Attempts int64 `json:"attempts"`
+	// Error of the last failed attempt
+	// @optional
+	LastError *string `json:"lastError,omitempty"`
+
+	QueuedAt *time.Time `json:"queuedAt"`
+	// When the task will next be attempted
+	// @optional
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+	// Set if the task failed too many times and won't be retried
+	// @optional
+	GaveUpAt *time.Time `json:"gaveUpAt,omitempty"`
+}
+
+// Stops a background task if it's running, and forgets about it.
+// Also used to dismiss tasks that were given up on.
+//
+// @name Tasks.Cancel
+// @category Tasks
+// @caller client
+type TasksCancelParams struct {
+	TaskID string `json:"taskId"`
+}
+
+func (p TasksCancelParams) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.TaskID, validation.Required),
+	)
+}
+
+type TasksCancelResult struct {
+	DidCancel bool `json:"didCancel"`
+}
+
 //----------------------------------------------------------------------
 // Misc.
 //----------------------------------------------------------------------
@@ -2715,6 +3594,10 @@ const (
 
 	// An install location could not be removed because it has active downloads
 	CodeCantRemoveLocationBecauseOfActiveDownloads Code = 18000
+
+	// There isn't enough free space in the install location, counting
+	// space reserved by other downloads
+	CodeInsufficientDiskSpace Code = 19000
 )
 
 // Dates
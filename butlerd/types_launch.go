@@ -64,3 +64,16 @@ const (
 	LaunchStrategyURL     LaunchStrategy = "url"
 	LaunchStrategyShell   LaunchStrategy = "shell"
 )
+
+type SandboxBackend string
+
+const (
+	// Firejail for now (Linux only)
+	SandboxBackendAuto SandboxBackend = ""
+	// Uses the system's bubblewrap (bwrap), Linux only. Games can only
+	// write to their temporary folder and a per-game folder that stands
+	// in for the home folder, XDG base directories included.
+	SandboxBackendBwrap SandboxBackend = "bwrap"
+	// Uses a firejail binary installed as a prerequisite, Linux only
+	SandboxBackendFirejail SandboxBackend = "firejail"
+)
@@ -0,0 +1,163 @@
+package butlerd
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/itchio/butler/butlerd/jsonrpc2"
+	"github.com/itchio/headway/state"
+	"github.com/pkg/errors"
+	"golang.org/x/net/websocket"
+)
+
+type ServeWebSocketParams struct {
+	Handler   jsonrpc2.Handler
+	Consumer  *state.Consumer
+	Listener  net.Listener
+	Secret    string
+	Log       bool
+	KeepAlive bool
+
+	// Origins browsers are allowed to connect from, like `https://example.org`.
+	// Connections without an `Origin` header (ie. not from a browser) are
+	// always allowed, since they still have to go through `Meta.Authenticate`.
+	AllowedOrigins []string
+
+	// If non-nil, serve over wss:// using the given (usually self-signed) certificate
+	TLSState *TLSState
+
+	ShutdownChan chan struct{}
+}
+
+// ServeWebSocket serves JSON-RPC 2.0 over WebSocket, one message per
+// WebSocket text frame. The same secret handshake as TCP applies.
+func (s *Server) ServeWebSocket(parentCtx context.Context, params ServeWebSocketParams) error {
+	ctx, cancel := context.WithCancel(parentCtx)
+	defer cancel()
+
+	// connections are only added to wg while the lock is held and we're
+	// not shutting down, so wg.Wait() can't race with wg.Add()
+	var wg sync.WaitGroup
+	var connsLock sync.Mutex
+	closing := false
+
+	wsServer := websocket.Server{
+		Handshake: func(config *websocket.Config, req *http.Request) error {
+			return checkWebSocketOrigin(req, params.AllowedOrigins)
+		},
+		Handler: func(ws *websocket.Conn) {
+			connsLock.Lock()
+			if closing {
+				connsLock.Unlock()
+				ws.Close()
+				return
+			}
+			wg.Add(1)
+			connsLock.Unlock()
+			defer wg.Done()
+
+			err := s.handleConn(ctx, params.Handler, params.Secret, newWebSocketTransport(ws))
+			if err != nil {
+				log.Printf("While handling WebSocket connection: %+v", err)
+			}
+
+			if !params.KeepAlive {
+				// without keep-alive, only serve a single connection
+				cancel()
+			}
+		},
+	}
+
+	httpServer := &http.Server{
+		Handler: wsServer,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if params.TLSState != nil {
+			tlsConfig := params.TLSState.Config.Clone()
+			// WebSocket upgrades only happen over HTTP/1.1
+			tlsConfig.NextProtos = []string{"http/1.1"}
+			serveErr <- httpServer.Serve(tls.NewListener(params.Listener, tlsConfig))
+		} else {
+			serveErr <- httpServer.Serve(params.Listener)
+		}
+	}()
+
+	select {
+	case err := <-serveErr:
+		if err != http.ErrServerClosed {
+			return errors.WithStack(err)
+		}
+	case <-params.ShutdownChan:
+		log.Printf("Closing WebSocket listener...")
+		err := httpServer.Close()
+		if err != nil {
+			log.Printf("While closing WebSocket listener: %+v", err)
+		}
+
+		connsLock.Lock()
+		closing = true
+		connsLock.Unlock()
+
+		log.Printf("Waiting for WebSocket connections to close...")
+		wg.Wait()
+		log.Printf("All WebSocket connections closed")
+	case <-ctx.Done():
+		httpServer.Close()
+	}
+
+	return nil
+}
+
+func checkWebSocketOrigin(req *http.Request, allowedOrigins []string) error {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+
+	for _, allowed := range allowedOrigins {
+		if origin == allowed {
+			return nil
+		}
+	}
+	return errors.Errorf("Origin not allowed: %s", origin)
+}
+
+type webSocketTransport struct {
+	ws *websocket.Conn
+}
+
+var _ jsonrpc2.Transport = (*webSocketTransport)(nil)
+
+func newWebSocketTransport(ws *websocket.Conn) jsonrpc2.Transport {
+	return &webSocketTransport{
+		ws: ws,
+	}
+}
+
+func (wt *webSocketTransport) Read() ([]byte, error) {
+	var msg []byte
+	err := websocket.Message.Receive(wt.ws, &msg)
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, errors.WithStack(err)
+	}
+	return msg, nil
+}
+
+func (wt *webSocketTransport) Write(msg []byte) error {
+	// sending a string (rather than a []byte) makes it a text frame
+	return websocket.Message.Send(wt.ws, string(msg))
+}
+
+func (wt *webSocketTransport) Close() error {
+	return wt.ws.Close()
+}
@@ -0,0 +1,50 @@
+package daemon
+
+import (
+	"context"
+	"os"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/client"
+	"github.com/itchio/butler/comm"
+	"github.com/itchio/butler/mansion"
+	"github.com/pkg/errors"
+)
+
+// WithClient starts a butler daemon on the itch app's database (or the one
+// given with --dbpath) and connects to it for the duration of f, for commands
+// that drive butlerd. mc.DBPath is set to the database used.
+//
+// The daemon is started even if the itch app is running, as there's no way
+// to connect to the app's. Both then share the database: SQLite keeps it
+// consistent, and the daemon started here leaves pending background tasks to
+// the app's, but neither knows what the other is doing, like which games
+// are running.
+func WithClient(mc *mansion.Context, f func(c *client.Client) error) error {
+	if mc.DBPath == "" {
+		comm.Debugf("DB path not specified (--dbpath), guessing...")
+		mc.DBPath = butlerd.GuessDBPath("")
+	}
+	comm.Debugf("Using database (%s)", mc.DBPath)
+
+	butlerPath, err := os.Executable()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	daemonCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c, err := client.Start(daemonCtx, client.StartParams{
+		ButlerPath: butlerPath,
+		DBPath:     mc.DBPath,
+		Args:       []string{"--no-resume-background-tasks"},
+		Stderr:     os.Stderr,
+	})
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	return f(c)
+}
@@ -2,6 +2,7 @@ package daemon
 
 import (
 	"context"
+	"log"
 	"net"
 	"os"
 	"path/filepath"
@@ -12,7 +13,11 @@ import (
 	"github.com/google/gops/agent"
 	"github.com/google/uuid"
 	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/mirror"
+	"github.com/itchio/butler/butlerd/netstatus"
+	"github.com/itchio/butler/cmd/operate"
 	"github.com/itchio/butler/database"
+	"github.com/itchio/butler/endpoints/install"
 	"github.com/itchio/headway/state"
 
 	"github.com/itchio/butler/comm"
@@ -21,18 +26,36 @@ import (
 )
 
 var args = struct {
-	destinyPids []int64
-	transport   string
-	keepAlive   bool
-	log         bool
+	destinyPids    []int64
+	transport      string
+	socketPath     string
+	wsOrigins      []string
+	wsTLS          bool
+	metrics        string
+	probeURL       string
+	buildCache     string
+	buildMirror    string
+	verifyInstalls bool
+	noResume       bool
+	keepAlive      bool
+	log            bool
 }{}
 
 func Register(ctx *mansion.Context) {
 	cmd := ctx.App.Command("daemon", "Start a butlerd instance").Hidden()
 	cmd.Flag("destiny-pid", "The daemon will shutdown whenever any of its destiny PIDs shuts down").Int64ListVar(&args.destinyPids)
-	cmd.Flag("transport", "Which transport to use").Default("tcp").EnumVar(&args.transport, "http", "tcp")
+	cmd.Flag("transport", "Which transport to use").Default("tcp").EnumVar(&args.transport, "http", "tcp", "unix", "ws")
+	cmd.Flag("socket-path", "Path of the unix socket (or named pipe on Windows) to listen on, when using the unix transport").StringVar(&args.socketPath)
+	cmd.Flag("ws-origin", "Origin browsers are allowed to connect from, when using the ws transport").StringsVar(&args.wsOrigins)
+	cmd.Flag("ws-tls", "Serve over wss:// with a self-signed certificate, when using the ws transport").BoolVar(&args.wsTLS)
 	cmd.Flag("keep-alive", "Accept multiple TCP connections, stay up until killed or a destiny PID shuts down").BoolVar(&args.keepAlive)
 	cmd.Flag("log", "Log all requests to stderr").BoolVar(&args.log)
+	cmd.Flag("metrics-address", "Serve Prometheus-style metrics over HTTP at this address, like 127.0.0.1:9090").StringVar(&args.metrics)
+	cmd.Flag("probe-url", "URL to probe when checking whether we're back online").Default(netstatus.DefaultProbeURL).StringVar(&args.probeURL)
+	cmd.Flag("build-cache", "Folder where build archives, patches and signatures are cached, so they can be served as a mirror").StringVar(&args.buildCache)
+	cmd.Flag("build-mirror", "Base URL of a mirror to download build archives, patches and signatures from, before trying itch.io").StringVar(&args.buildMirror)
+	cmd.Flag("verify-installs", "Verify installs to all install locations against their build's signature once they're done").BoolVar(&args.verifyInstalls)
+	cmd.Flag("no-resume-background-tasks", "Don't resume background tasks left pending in the database, for daemons that share it with another one").BoolVar(&args.noResume)
 	ctx.Register(cmd, do)
 }
 
@@ -43,6 +66,11 @@ func do(ctx *mansion.Context) {
 	}
 
 	ctx.EnsureDBPath()
+	netstatus.SetProbeURL(args.probeURL)
+	mirror.SetCacheDir(args.buildCache)
+	mirror.SetBaseURL(args.buildMirror)
+	operate.SetVerifyAllInstalls(args.verifyInstalls)
+	install.SetDBPath(ctx.DBPath)
 
 	err := agent.Listen(agent.Options{
 		Addr:            "localhost:0",
@@ -106,6 +134,38 @@ func Do(mansionContext *mansion.Context, ctx context.Context, dbPool *sqlitex.Po
 	router := GetRouter(dbPool, mansionContext)
 	consumer := comm.NewStateConsumer()
 
+	if args.log {
+		router.Use(butlerd.LogMiddleware(&state.Consumer{
+			OnMessage: func(lvl string, msg string) {
+				log.Printf("[butlerd] [%s] %s", lvl, msg)
+			},
+		}))
+	}
+
+	if !args.noResume {
+		err := router.ResumeBackgroundTasks()
+		if err != nil {
+			return err
+		}
+	}
+
+	if args.metrics != "" {
+		err := serveMetrics(router, args.metrics)
+		if err != nil {
+			return err
+		}
+	}
+
+	serveParams := butlerd.ServeTCPParams{
+		Handler:   router,
+		Consumer:  consumer,
+		Secret:    secret,
+		Log:       args.log,
+		KeepAlive: args.keepAlive,
+
+		ShutdownChan: router.ShutdownChan,
+	}
+
 	switch args.transport {
 	case "tcp":
 		listener, err := net.Listen("tcp", "127.0.0.1:")
@@ -120,13 +180,66 @@ func Do(mansionContext *mansion.Context, ctx context.Context, dbPool *sqlitex.Po
 			},
 		})
 
-		err = s.ServeTCP(ctx, butlerd.ServeTCPParams{
-			Handler:   router,
-			Consumer:  consumer,
-			Listener:  listener,
-			Secret:    secret,
-			Log:       args.log,
-			KeepAlive: args.keepAlive,
+		serveParams.Listener = listener
+		err = s.ServeTCP(ctx, serveParams)
+		if err != nil {
+			return err
+		}
+	case "unix":
+		listener, cleanup, err := listenLocal(args.socketPath)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		comm.Object("butlerd/listen-notification", map[string]interface{}{
+			"secret": secret,
+			"unix": map[string]interface{}{
+				"path": listener.Addr().String(),
+			},
+		})
+
+		serveParams.Listener = listener
+		err = s.ServeTCP(ctx, serveParams)
+		if err != nil {
+			return err
+		}
+	case "ws":
+		listener, err := net.Listen("tcp", "127.0.0.1:")
+		if err != nil {
+			return err
+		}
+
+		wsBlock := map[string]interface{}{
+			"address": listener.Addr().String(),
+		}
+
+		var tlsState *butlerd.TLSState
+		if args.wsTLS {
+			tlsState, err = butlerd.MakeTLSState()
+			if err != nil {
+				return err
+			}
+			wsBlock["url"] = "wss://" + listener.Addr().String()
+			wsBlock["ca"] = string(tlsState.CertPEMBlock)
+		} else {
+			wsBlock["url"] = "ws://" + listener.Addr().String()
+		}
+
+		comm.Object("butlerd/listen-notification", map[string]interface{}{
+			"secret":    secret,
+			"websocket": wsBlock,
+		})
+
+		err = s.ServeWebSocket(ctx, butlerd.ServeWebSocketParams{
+			Handler:        router,
+			Consumer:       consumer,
+			Listener:       listener,
+			Secret:         secret,
+			Log:            args.log,
+			KeepAlive:      args.keepAlive,
+			AllowedOrigins: args.wsOrigins,
+			TLSState:       tlsState,
 
 			ShutdownChan: router.ShutdownChan,
 		})
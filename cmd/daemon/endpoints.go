@@ -6,6 +6,7 @@ import (
 	"github.com/itchio/butler/butlerd/messages"
 	"github.com/itchio/butler/endpoints/cleandownloads"
 	"github.com/itchio/butler/endpoints/downloads"
+	"github.com/itchio/butler/endpoints/events"
 	"github.com/itchio/butler/endpoints/fetch"
 	"github.com/itchio/butler/endpoints/install"
 	"github.com/itchio/butler/endpoints/launch"
@@ -13,6 +14,7 @@ import (
 	"github.com/itchio/butler/endpoints/profile"
 	"github.com/itchio/butler/endpoints/search"
 	"github.com/itchio/butler/endpoints/system"
+	"github.com/itchio/butler/endpoints/tasks"
 	"github.com/itchio/butler/endpoints/tests"
 	"github.com/itchio/butler/endpoints/update"
 	"github.com/itchio/butler/endpoints/utilities"
@@ -40,6 +42,8 @@ func GetRouter(dbPool *sqlitex.Pool, mansionContext *mansion.Context) *butlerd.R
 	downloads.Register(mainRouter)
 	search.Register(mainRouter)
 	system.Register(mainRouter)
+	tasks.Register(mainRouter)
+	events.Register(mainRouter)
 
 	messages.EnsureAllRequests(mainRouter)
 
@@ -0,0 +1,69 @@
+//+build !windows
+
+package daemon
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// listenLocal listens on a unix domain socket that only the current
+// user can connect to. If socketPath is empty, the socket is created
+// in a fresh, private temporary directory.
+func listenLocal(socketPath string) (net.Listener, func(), error) {
+	cleanup := func() {}
+
+	if socketPath == "" {
+		dir, err := ioutil.TempDir("", "butlerd")
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		cleanup = func() {
+			os.RemoveAll(dir)
+		}
+		socketPath = filepath.Join(dir, "butlerd.sock")
+	} else {
+		// a previous instance might have left a stale socket behind,
+		// but we're not about to delete anything else that's there.
+		stats, err := os.Lstat(socketPath)
+		if err == nil {
+			if stats.Mode()&os.ModeSocket == 0 {
+				return nil, nil, errors.Errorf("refusing to listen on (%s): it exists and is not a socket", socketPath)
+			}
+			err = os.Remove(socketPath)
+			if err != nil {
+				return nil, nil, errors.WithStack(err)
+			}
+		} else if !os.IsNotExist(err) {
+			return nil, nil, errors.WithStack(err)
+		}
+
+		cleanup = func() {
+			os.Remove(socketPath)
+		}
+	}
+
+	// make sure the socket is never accessible to other users,
+	// not even in between listen and chmod.
+	oldUmask := syscall.Umask(0o177)
+	listener, err := net.Listen("unix", socketPath)
+	syscall.Umask(oldUmask)
+	if err != nil {
+		cleanup()
+		return nil, nil, errors.WithStack(err)
+	}
+
+	err = os.Chmod(socketPath, 0o600)
+	if err != nil {
+		listener.Close()
+		cleanup()
+		return nil, nil, errors.WithStack(err)
+	}
+
+	return listener, cleanup, nil
+}
@@ -0,0 +1,47 @@
+//+build !windows
+
+package daemon
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ListenLocal(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "butlerd-listen")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	// never delete something that isn't a socket
+	notASocket := filepath.Join(dir, "important.txt")
+	assert.NoError(ioutil.WriteFile(notASocket, []byte("precious"), 0o644))
+	_, _, err = listenLocal(notASocket)
+	assert.Error(err)
+	assert.FileExists(notASocket)
+
+	// a stale socket from a previous instance is replaced
+	socketPath := filepath.Join(dir, "butlerd.sock")
+	stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: socketPath, Net: "unix"})
+	assert.NoError(err)
+	stale.SetUnlinkOnClose(false)
+	stale.Close()
+	assert.FileExists(socketPath)
+
+	listener, cleanup, err := listenLocal(socketPath)
+	assert.NoError(err)
+	stats, err := os.Lstat(socketPath)
+	assert.NoError(err)
+	assert.EqualValues(os.FileMode(0o600), stats.Mode().Perm())
+
+	listener.Close()
+	cleanup()
+	_, err = os.Lstat(socketPath)
+	assert.True(os.IsNotExist(err))
+}
@@ -0,0 +1,48 @@
+//+build windows
+
+package daemon
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/Microsoft/go-winio"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/windows"
+)
+
+// listenLocal listens on a named pipe that only the current user can
+// connect to. If socketPath is empty, a random pipe name is picked.
+func listenLocal(socketPath string) (net.Listener, func(), error) {
+	if socketPath == "" {
+		socketPath = fmt.Sprintf(`\\.\pipe\butlerd-%s`, uuid.New().String())
+	}
+
+	sddl, err := currentUserOnlySDDL()
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	listener, err := winio.ListenPipe(socketPath, &winio.PipeConfig{
+		SecurityDescriptor: sddl,
+	})
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	return listener, func() {}, nil
+}
+
+// currentUserOnlySDDL returns a security descriptor that grants the current
+// user full access and nobody else anything. The default one lets everyone
+// connect, which isn't what we want for butlerd.
+func currentUserOnlySDDL() (string, error) {
+	tokenUser, err := windows.GetCurrentProcessToken().GetTokenUser()
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	// P: don't inherit entries, GA: generic all
+	return fmt.Sprintf("D:P(A;;GA;;;%s)", tokenUser.User.Sid.String()), nil
+}
@@ -0,0 +1,34 @@
+package daemon
+
+import (
+	"log"
+	"net"
+	"net/http"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/metrics"
+	"github.com/itchio/butler/comm"
+	"github.com/pkg/errors"
+)
+
+func serveMetrics(router *butlerd.Router, address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return errors.WithMessage(err, "listening for metrics")
+	}
+
+	router.Use(butlerd.MetricsMiddleware())
+	router.RegisterMetrics()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Default.Handler())
+
+	comm.Logf("butlerd: serving metrics on http://%s/metrics", listener.Addr())
+	go func() {
+		err := http.Serve(listener, mux)
+		if err != nil {
+			log.Printf("While serving metrics: %+v", err)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,81 @@
+package downloads
+
+import (
+	"path/filepath"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/client"
+	"github.com/itchio/butler/cmd/daemon"
+	"github.com/itchio/butler/comm"
+	"github.com/itchio/butler/mansion"
+	"github.com/pkg/errors"
+)
+
+var exportArgs = struct {
+	file *string
+}{}
+
+var importArgs = struct {
+	file              *string
+	installLocationID *string
+}{}
+
+func Register(ctx *mansion.Context) {
+	parentCmd := ctx.App.Command("downloads", "Export or import the itch app's download queue").Hidden()
+
+	{
+		cmd := parentCmd.Command("export", "Write pending downloads to a manifest file")
+		exportArgs.file = cmd.Arg("file", "Path of the manifest to write").Required().String()
+		ctx.Register(cmd, doExport)
+	}
+
+	{
+		cmd := parentCmd.Command("import", "Queue the downloads listed in a manifest file")
+		importArgs.file = cmd.Arg("file", "Path of the manifest to read").Required().String()
+		importArgs.installLocationID = cmd.Flag("install-location", "ID of the install location to queue downloads to").String()
+		ctx.Register(cmd, doImport)
+	}
+}
+
+func doExport(mc *mansion.Context) {
+	mc.Must(daemon.WithClient(mc, func(c *client.Client) error {
+		path, err := filepath.Abs(*exportArgs.file)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		res, err := c.DownloadsExport(butlerd.DownloadsExportParams{
+			Path: path,
+		})
+		if err != nil {
+			return err
+		}
+		comm.Statf("Exported %d downloads to (%s)", len(res.Manifest.Entries), path)
+		return nil
+	}))
+}
+
+func doImport(mc *mansion.Context) {
+	mc.Must(daemon.WithClient(mc, func(c *client.Client) error {
+		path, err := filepath.Abs(*importArgs.file)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		res, err := c.DownloadsImport(butlerd.DownloadsImportParams{
+			Path:              path,
+			InstallLocationID: *importArgs.installLocationID,
+		})
+		if err != nil {
+			return err
+		}
+		for _, f := range res.Failed {
+			comm.Warnf("Could not queue game %d, upload %d: %s", f.Entry.GameID, f.Entry.UploadID, f.Error)
+		}
+		comm.Statf("Queued %d downloads, %d failed", len(res.Items), len(res.Failed))
+		if len(res.Failed) > 0 {
+			return errors.Errorf("%d downloads could not be queued", len(res.Failed))
+		}
+		return nil
+	}))
+}
@@ -0,0 +1,87 @@
+package launch
+
+import (
+	"path/filepath"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/client"
+	"github.com/itchio/butler/cmd/daemon"
+	"github.com/itchio/butler/comm"
+	"github.com/itchio/butler/mansion"
+	"github.com/pkg/errors"
+	"github.com/skratchdot/open-golang/open"
+)
+
+var args = struct {
+	caveID     *string
+	prereqsDir *string
+}{}
+
+func Register(ctx *mansion.Context) {
+	cmd := ctx.App.Command("launch", "Launch an installed game, like the itch app would. Used by shortcuts.").Hidden()
+	args.caveID = cmd.Arg("cave-id", "ID of the cave to launch").Required().String()
+	args.prereqsDir = cmd.Flag("prereqs-dir", "Where to store installers for prerequisites, defaults to the one next to the database").String()
+	ctx.Register(cmd, do)
+}
+
+func do(mc *mansion.Context) {
+	mc.Must(daemon.WithClient(mc, func(c *client.Client) error {
+		return Do(mc, c, *args.caveID)
+	}))
+}
+
+func Do(mc *mansion.Context, c *client.Client, caveID string) error {
+	prereqsDir := *args.prereqsDir
+	if prereqsDir == "" {
+		// the itch app keeps its database in `{userData}/db/butler.db`
+		// and prerequisites in `{userData}/prereqs`
+		prereqsDir = filepath.Join(filepath.Dir(filepath.Dir(mc.DBPath)), "prereqs")
+	}
+
+	c.OnAcceptLicense(func(params butlerd.AcceptLicenseParams) (*butlerd.AcceptLicenseResult, error) {
+		comm.Logf("%s", params.Text)
+		return &butlerd.AcceptLicenseResult{
+			Accept: comm.YesNo("Accept license agreement?"),
+		}, nil
+	})
+	c.OnPickManifestAction(func(params butlerd.PickManifestActionParams) (*butlerd.PickManifestActionResult, error) {
+		// there's no one to ask, go with the first action
+		comm.Logf("Picking action (%s)", params.Actions[0].Name)
+		return &butlerd.PickManifestActionResult{
+			Index: 0,
+		}, nil
+	})
+	c.OnAllowSandboxSetup(func(params butlerd.AllowSandboxSetupParams) (*butlerd.AllowSandboxSetupResult, error) {
+		return &butlerd.AllowSandboxSetupResult{
+			Allow: false,
+		}, nil
+	})
+	c.OnPrereqsFailed(func(params butlerd.PrereqsFailedParams) (*butlerd.PrereqsFailedResult, error) {
+		comm.Warnf("Could not install prerequisites: %s", params.Error)
+		return &butlerd.PrereqsFailedResult{
+			Continue: comm.YesNo("Launch anyway?"),
+		}, nil
+	})
+	c.OnShellLaunch(func(params butlerd.ShellLaunchParams) (*butlerd.ShellLaunchResult, error) {
+		return &butlerd.ShellLaunchResult{}, errors.WithStack(open.Start(params.ItemPath))
+	})
+	c.OnURLLaunch(func(params butlerd.URLLaunchParams) (*butlerd.URLLaunchResult, error) {
+		return &butlerd.URLLaunchResult{}, errors.WithStack(open.Start(params.URL))
+	})
+	c.OnHTMLLaunch(func(params butlerd.HTMLLaunchParams) (*butlerd.HTMLLaunchResult, error) {
+		// without the itch app, the best we can do is a web browser
+		indexPath := filepath.Join(params.RootFolder, filepath.FromSlash(params.IndexPath))
+		return &butlerd.HTMLLaunchResult{}, errors.WithStack(open.Start(indexPath))
+	})
+
+	comm.Opf("Launching cave (%s)", caveID)
+	_, err := c.Launch(butlerd.LaunchParams{
+		CaveID:     caveID,
+		PrereqsDir: prereqsDir,
+	})
+	if err != nil {
+		return err
+	}
+	comm.Statf("Done launching cave (%s)", caveID)
+	return nil
+}
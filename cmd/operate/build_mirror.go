@@ -0,0 +1,219 @@
+package operate
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/itchio/butler/butlerd/mirror"
+	itchio "github.com/itchio/go-itchio"
+	"github.com/itchio/httpkit/eos"
+	"github.com/pkg/errors"
+)
+
+// A buildFileSource tells where to get a build file from, see package mirror
+type buildFileSource struct {
+	// URL (or path) of the build file in the cache folder or on the mirror,
+	// empty if it should come from itch.io
+	MirroredURL string
+
+	// Where to cache the build file once it's been downloaded from
+	// itch.io, empty if it shouldn't be
+	CachePath string
+}
+
+// findBuildFileSource looks for a build file in the cache folder, then on
+// the mirror. Files whose size doesn't match what itch.io says are ignored.
+//
+// Callers that end up using a mirrored file must verify the install
+// against the build's signature, from itch.io, see verifyInstall.
+// Only files downloaded from itch.io are cached, see cacheBuildFile
+// and fetchBuildFile.
+func findBuildFileSource(oc *OperationContext, client *itchio.Client, build *itchio.Build, p itchio.MakeBuildDownloadURLParams) buildFileSource {
+	consumer := oc.Consumer()
+
+	var src buildFileSource
+	c := mirror.Get()
+	if !c.Enabled() || build == nil {
+		return src
+	}
+
+	if p.SubType == "" {
+		p.SubType = itchio.BuildFileSubTypeDefault
+	}
+	key := mirror.Key(build.ID, p.Type, p.SubType)
+
+	expectedSize, err := buildFileSize(oc, client, build, p)
+	if err != nil {
+		consumer.Warnf("Could not get size of build file (%s), not using mirror: %v", key, err)
+		return src
+	}
+
+	if c.IsCached(build.ID, p.Type, p.SubType, expectedSize) {
+		consumer.Infof("Using build file (%s) from cache", key)
+		src.MirroredURL = c.CachePath(build.ID, p.Type, p.SubType)
+		return src
+	}
+
+	if mirrorURL := c.URL(build.ID, p.Type, p.SubType); mirrorURL != "" {
+		size, err := remoteSize(oc, mirrorURL)
+		if err != nil {
+			consumer.Infof("Build file (%s) not available from mirror: %v", key, err)
+		} else if size != expectedSize {
+			consumer.Warnf("Build file (%s) on mirror has size %d, expected %d, ignoring it", key, size, expectedSize)
+		} else {
+			consumer.Infof("Using build file (%s) from mirror", key)
+			src.MirroredURL = mirrorURL
+			return src
+		}
+	}
+
+	src.CachePath = c.CachePath(build.ID, p.Type, p.SubType)
+	return src
+}
+
+// fetchBuildFile returns where to read a build file from, and whether
+// it's from the cache folder or mirror. Build files that should be cached
+// are downloaded from itch.io to the cache folder first, see downloadToCache.
+func fetchBuildFile(oc *OperationContext, client *itchio.Client, build *itchio.Build, p itchio.MakeBuildDownloadURLParams) (string, bool) {
+	consumer := oc.Consumer()
+
+	src := findBuildFileSource(oc, client, build, p)
+	if src.MirroredURL != "" {
+		return src.MirroredURL, true
+	}
+
+	url := client.MakeBuildDownloadURL(p)
+	if src.CachePath == "" {
+		return url, false
+	}
+
+	err := downloadToCache(oc, url, src.CachePath)
+	if err != nil {
+		consumer.Warnf("Could not cache build file: %+v", err)
+		return url, false
+	}
+	return src.CachePath, false
+}
+
+// buildFileSize returns the size itch.io has for a build file. When the
+// build's files aren't listed, the file itself is asked for its size.
+func buildFileSize(oc *OperationContext, client *itchio.Client, build *itchio.Build, p itchio.MakeBuildDownloadURLParams) (int64, error) {
+	files := build.Files
+	if len(files) == 0 {
+		buildRes, err := client.GetBuild(oc.ctx, itchio.GetBuildParams{
+			BuildID:     build.ID,
+			Credentials: p.Credentials,
+		})
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+		files = buildRes.Build.Files
+	}
+
+	if len(files) == 0 {
+		return remoteSize(oc, client.MakeBuildDownloadURL(p))
+	}
+
+	bf := FindBuildFile(files, p.Type, p.SubType)
+	if bf == nil {
+		return 0, errors.Errorf("not found on itch.io")
+	}
+	return bf.Size, nil
+}
+
+func remoteSize(oc *OperationContext, url string) (int64, error) {
+	f, err := eos.Open(url, oc.EOSOptions()...)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	defer f.Close()
+
+	stats, err := f.Stat()
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return stats.Size(), nil
+}
+
+// cacheBuildFile moves a build file that was downloaded to the staging
+// folder into the cache folder. If they're on different volumes, it's
+// copied instead, see writeCacheFile.
+func cacheBuildFile(oc *OperationContext, stagedPath string, cachePath string) error {
+	consumer := oc.Consumer()
+	consumer.Infof("Caching build file to (%s)...", cachePath)
+
+	err := os.MkdirAll(filepath.Dir(cachePath), 0o755)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = os.Rename(stagedPath, cachePath)
+	if err == nil {
+		return nil
+	}
+
+	r, err := os.Open(stagedPath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer r.Close()
+
+	return writeCacheFile(oc, r, cachePath)
+}
+
+// downloadToCache downloads a build file from itch.io straight to the
+// cache folder, for build files that are read whole, like patches
+// and signatures.
+func downloadToCache(oc *OperationContext, url string, cachePath string) error {
+	consumer := oc.Consumer()
+	consumer.Infof("Downloading build file to cache (%s)...", cachePath)
+
+	err := os.MkdirAll(filepath.Dir(cachePath), 0o755)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	f, err := eos.Open(url, oc.EOSOptions()...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer f.Close()
+
+	return writeCacheFile(oc, f, cachePath)
+}
+
+// writeCacheFile writes a build file next to its final location first,
+// under a name that's unique to this operation, so that partial files
+// are never used, and concurrent downloads of the same build file don't
+// step on each other.
+func writeCacheFile(oc *OperationContext, r io.Reader, cachePath string) error {
+	partPath := fmt.Sprintf("%s.%s.part", cachePath, filepath.Base(oc.StageFolder()))
+	defer os.Remove(partPath)
+
+	err := writeFile(partPath, r)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(partPath, cachePath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func writeFile(dst string, r io.Reader) error {
+	w, err := os.Create(dst)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer w.Close()
+
+	_, err = io.Copy(w, r)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(w.Close())
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/itchio/butler/cmd/wipe"
 	"github.com/itchio/butler/database/models"
 	"github.com/itchio/headway/state"
+	"github.com/itchio/httpkit/eos/option"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
 )
@@ -190,6 +191,16 @@ func (oc *OperationContext) Ctx() context.Context {
 	return oc.ctx
 }
 
+// EOSOptions returns the options to open remote files with, so that
+// they're throttled along with the rest of the download, if needed.
+func (oc *OperationContext) EOSOptions() []option.Option {
+	opts := []option.Option{option.WithConsumer(oc.consumer)}
+	if oc.rc.Throttle != nil {
+		opts = append(opts, option.WithHTTPClient(oc.rc.Throttle.HTTPClient()))
+	}
+	return opts
+}
+
 func contextPath(stageFolder string) string {
 	return filepath.Join(stageFolder, "operate-context.json")
 }
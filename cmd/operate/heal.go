@@ -6,13 +6,14 @@ import (
 
 	"github.com/itchio/butler/butlerd"
 	"github.com/itchio/butler/butlerd/messages"
+	"github.com/itchio/butler/butlerd/mirror"
+	itchio "github.com/itchio/go-itchio"
 	"github.com/itchio/hush"
 	"github.com/itchio/hush/bfs"
 
 	"github.com/itchio/savior/seeksource"
 
 	"github.com/itchio/httpkit/eos"
-	"github.com/itchio/httpkit/eos/option"
 
 	"github.com/itchio/headway/united"
 
@@ -22,7 +23,16 @@ import (
 	"github.com/pkg/errors"
 )
 
-func heal(oc *OperationContext, meta *MetaSubcontext, isub *InstallSubcontext, receiptIn *bfs.Receipt) error {
+// knownWounds are wounds that were already found by validating an install
+// against sigInfo, see verifyInstall.
+type knownWounds struct {
+	SigInfo *pwr.SignatureInfo
+	Wounds  []*pwr.Wound
+}
+
+// heal repairs an install from the build's archive. If known is nil, the
+// whole install is validated first, otherwise only the known wounds are healed.
+func heal(oc *OperationContext, meta *MetaSubcontext, isub *InstallSubcontext, receiptIn *bfs.Receipt, known *knownWounds) error {
 	consumer := oc.Consumer()
 	istate := isub.Data
 	params := meta.Data
@@ -41,71 +51,91 @@ func heal(oc *OperationContext, meta *MetaSubcontext, isub *InstallSubcontext, r
 
 	client := oc.rc.Client(params.Access.APIKey)
 
-	signatureURL := MakeSourceURL(client, consumer, istate.DownloadSessionID, params, "signature")
 	archiveURL := MakeSourceURL(client, consumer, istate.DownloadSessionID, params, "archive")
-
-	healSpec := fmt.Sprintf("archive,%s", archiveURL)
-
-	vc := &pwr.ValidatorContext{
-		Consumer: consumer,
-		HealPath: healSpec,
-	}
-
-	signatureFile, err := eos.Open(signatureURL, option.WithConsumer(consumer))
-	if err != nil {
-		return errors.WithStack(err)
+	// if a mirrored install turned out wounded, the mirror may be why,
+	// heal from itch.io. Only parts of the archive are read, so it's
+	// not worth caching.
+	if known == nil || !istate.UsedMirror {
+		src := findBuildFileSource(oc, client, params.Build, itchio.MakeBuildDownloadURLParams{
+			BuildID:     params.Build.ID,
+			UUID:        istate.DownloadSessionID,
+			Credentials: params.Access.Credentials,
+			Type:        itchio.BuildFileTypeArchive,
+		})
+		if src.MirroredURL != "" {
+			archiveURL = src.MirroredURL
+			istate.UsedMirror = true
+			err := oc.Save(isub)
+			if err != nil {
+				return err
+			}
+		}
 	}
-	defer signatureFile.Close()
 
-	stat, err := signatureFile.Stat()
-	if err != nil {
-		return errors.WithStack(err)
-	}
-
-	consumer.Infof("Fetching + parsing %s signature...",
-		united.FormatBytes(stat.Size()),
-	)
-
-	signatureSource := seeksource.FromFile(signatureFile)
-
-	timeBeforeSig := time.Now()
-
-	_, err = signatureSource.Resume(nil)
-	if err != nil {
-		return errors.WithStack(err)
+	// the archive healer opens archiveURL itself, with eos's default
+	// HTTP client, route it through this download's throttle.
+	if oc.rc.Throttle != nil {
+		defer oc.rc.Throttle.Route(archiveURL)()
 	}
+	healSpec := fmt.Sprintf("archive,%s", archiveURL)
 
-	sigInfo, err := pwr.ReadSignature(oc.ctx, signatureSource)
-	if err != nil {
-		return errors.WithStack(err)
+	var sigInfo *pwr.SignatureInfo
+	var err error
+	if known != nil {
+		sigInfo = known.SigInfo
+	} else {
+		sigInfo, err = fetchSignature(oc, meta, isub)
+		if err != nil {
+			return err
+		}
 	}
 
-	consumer.Infof("✓ Fetched signature in %s, dealing with %s container",
-		time.Since(timeBeforeSig),
-		united.FormatBytes(sigInfo.Container.Size),
-	)
-
 	consumer.Infof("Healing container...")
 
 	timeBeforeHeal := time.Now()
 
+	var woundsConsumer pwr.WoundsConsumer
+	var appliedCaseFixes bool
+
 	oc.rc.StartProgress()
-	err = vc.Validate(oc.ctx, params.InstallFolder, sigInfo)
-	oc.rc.EndProgress()
-	if err != nil {
-		return errors.WithStack(err)
+	if known != nil {
+		healer, err := pwr.NewHealer(healSpec, params.InstallFolder)
+		if err != nil {
+			oc.rc.EndProgress()
+			return errors.WithStack(err)
+		}
+		healer.SetConsumer(consumer)
+		woundsConsumer = healer
+
+		err = healWounds(oc, healer, sigInfo.Container, known.Wounds)
+		oc.rc.EndProgress()
+		if err != nil {
+			return err
+		}
+	} else {
+		vc := &pwr.ValidatorContext{
+			Consumer: consumer,
+			HealPath: healSpec,
+		}
+		err = vc.Validate(oc.ctx, params.InstallFolder, sigInfo)
+		oc.rc.EndProgress()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		woundsConsumer = vc.WoundsConsumer
+		appliedCaseFixes = vc.CaseFixStats != nil && len(vc.CaseFixStats.Fixes) > 0
 	}
 
 	healDuration := time.Since(timeBeforeHeal)
 	containerSize := sigInfo.Container.Size
 
-	if vc.WoundsConsumer.HasWounds() {
-		if healer, ok := vc.WoundsConsumer.(pwr.Healer); ok {
+	if woundsConsumer.HasWounds() {
+		if healer, ok := woundsConsumer.(pwr.Healer); ok {
 			totalHealed := healer.TotalHealed()
 			perSec := united.FormatBPS(totalHealed, healDuration)
 
 			consumer.Infof("✓ %s corrupted data found (of %s total), %s healed @ %s/s, %s total",
-				united.FormatBytes(vc.WoundsConsumer.TotalCorrupted()),
+				united.FormatBytes(woundsConsumer.TotalCorrupted()),
 				united.FormatBytes(sigInfo.Container.Size),
 				united.FormatBytes(totalHealed),
 				perSec,
@@ -113,7 +143,7 @@ func heal(oc *OperationContext, meta *MetaSubcontext, isub *InstallSubcontext, r
 			)
 		} else {
 			consumer.Warnf("%s corrupted data found (of %s total)",
-				united.FormatBytes(vc.WoundsConsumer.TotalCorrupted()),
+				united.FormatBytes(woundsConsumer.TotalCorrupted()),
 				united.FormatBytes(sigInfo.Container.Size),
 			)
 		}
@@ -129,8 +159,8 @@ func heal(oc *OperationContext, meta *MetaSubcontext, isub *InstallSubcontext, r
 
 	err = isub.EventSink(oc).PostEvent(hush.InstallEvent{
 		Heal: &hush.HealInstallEvent{
-			TotalCorrupted:   vc.WoundsConsumer.TotalCorrupted(),
-			AppliedCaseFixes: vc.CaseFixStats != nil && len(vc.CaseFixStats.Fixes) > 0,
+			TotalCorrupted:   woundsConsumer.TotalCorrupted(),
+			AppliedCaseFixes: appliedCaseFixes,
 		},
 	})
 	if err != nil {
@@ -172,6 +202,87 @@ func heal(oc *OperationContext, meta *MetaSubcontext, isub *InstallSubcontext, r
 	})
 }
 
+// healWounds feeds wounds to healer, and waits for it to be done.
+func healWounds(oc *OperationContext, healer pwr.Healer, container *tlc.Container, wounds []*pwr.Wound) error {
+	woundsChan := make(chan *pwr.Wound)
+	errs := make(chan error, 1)
+
+	go func() {
+		errs <- healer.Do(oc.ctx, container, woundsChan)
+	}()
+
+	for _, wound := range wounds {
+		select {
+		case woundsChan <- wound:
+			// all good
+		case err := <-errs:
+			return errors.WithStack(err)
+		}
+	}
+	close(woundsChan)
+
+	return errors.WithStack(<-errs)
+}
+
+// fetchSignature downloads and parses the signature of the build being
+// installed, from itch.io.
+func fetchSignature(oc *OperationContext, meta *MetaSubcontext, isub *InstallSubcontext) (*pwr.SignatureInfo, error) {
+	consumer := oc.Consumer()
+	istate := isub.Data
+	params := meta.Data
+
+	client := oc.rc.Client(params.Access.APIKey)
+	signatureURL := MakeSourceURL(client, consumer, istate.DownloadSessionID, params, "signature")
+	// always fetched from itch.io, since installs are checked against it,
+	// but kept in the cache folder so it can be served as a mirror
+	if params.Build != nil {
+		cachePath := mirror.Get().CachePath(params.Build.ID, itchio.BuildFileTypeSignature, itchio.BuildFileSubTypeDefault)
+		if cachePath != "" {
+			err := downloadToCache(oc, signatureURL, cachePath)
+			if err != nil {
+				consumer.Warnf("Could not cache signature: %+v", err)
+			} else {
+				signatureURL = cachePath
+			}
+		}
+	}
+
+	signatureFile, err := eos.Open(signatureURL, oc.EOSOptions()...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer signatureFile.Close()
+
+	stat, err := signatureFile.Stat()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	consumer.Infof("Fetching + parsing %s signature...",
+		united.FormatBytes(stat.Size()),
+	)
+
+	signatureSource := seeksource.FromFile(signatureFile)
+
+	timeBeforeSig := time.Now()
+
+	_, err = signatureSource.Resume(nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	sigInfo, err := pwr.ReadSignature(oc.ctx, signatureSource)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	consumer.Infof("✓ Fetched signature in %s, dealing with %s container",
+		time.Since(timeBeforeSig),
+		united.FormatBytes(sigInfo.Container.Size),
+	)
+	return sigInfo, nil
+}
+
 func resultForContainer(c *tlc.Container) *hush.InstallResult {
 	res := &hush.InstallResult{
 		Files: nil,
@@ -3,6 +3,7 @@ package operate
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/itchio/butler/manager/runlock"
@@ -24,28 +25,42 @@ import (
 )
 
 func InstallPerform(ctx context.Context, rc *butlerd.RequestContext, performParams butlerd.InstallPerformParams) (*butlerd.InstallPerformResult, error) {
+	res, _, err := InstallPerformWithStrategy(ctx, rc, performParams)
+	return res, err
+}
+
+// InstallPerformWithStrategy is like InstallPerform but also returns which
+// strategy was used ("install", "upgrade", "heal", or "none" if it failed
+// before picking one), whether it succeeded or not.
+func InstallPerformWithStrategy(ctx context.Context, rc *butlerd.RequestContext, performParams butlerd.InstallPerformParams) (*butlerd.InstallPerformResult, string, error) {
+	none := strategyName(InstallPerformStrategyNone)
 	if performParams.StagingFolder == "" {
-		return nil, errors.New("No staging folder specified")
+		return nil, none, errors.New("No staging folder specified")
 	}
 
 	oc, err := LoadContext(ctx, rc, performParams.StagingFolder)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, none, errors.WithStack(err)
 	}
 	defer oc.Release()
 
 	meta := NewMetaSubcontext()
 	oc.Load(meta)
 
-	res, err := doInstallPerform(oc, meta)
+	if scope := rc.EventScope(); scope.CaveID == "" {
+		scope.CaveID = meta.Data.CaveID
+		rc.SetEventScope(scope)
+	}
+
+	res, strategy, err := doInstallPerform(oc, meta)
 	if err != nil {
 		oc.Consumer().Errorf("%+v", err)
-		return nil, errors.WithStack(err)
+		return nil, strategyName(strategy), errors.WithStack(err)
 	}
 
 	oc.Retire()
 
-	return res, nil
+	return res, strategyName(strategy), nil
 }
 
 func doForceLocal(file eos.File, oc *OperationContext, meta *MetaSubcontext, isub *InstallSubcontext) (eos.File, error) {
@@ -58,8 +73,7 @@ func doForceLocal(file eos.File, oc *OperationContext, meta *MetaSubcontext, isu
 		return nil, errors.WithStack(err)
 	}
 
-	destName := filepath.Base(stats.Name())
-	destPath := filepath.Join(oc.StageFolder(), "install-source", destName)
+	destPath := localSourcePath(oc, stats)
 
 	if istate.IsAvailableLocally {
 		consumer.Infof("Install source needs to be available locally, re-using previously-downloaded file")
@@ -121,7 +135,12 @@ func doForceLocal(file eos.File, oc *OperationContext, meta *MetaSubcontext, isu
 	return ret, nil
 }
 
-func doInstallPerform(oc *OperationContext, meta *MetaSubcontext) (*butlerd.InstallPerformResult, error) {
+// localSourcePath returns where doForceLocal downloads an install source
+func localSourcePath(oc *OperationContext, stats os.FileInfo) string {
+	return filepath.Join(oc.StageFolder(), "install-source", filepath.Base(stats.Name()))
+}
+
+func doInstallPerform(oc *OperationContext, meta *MetaSubcontext) (*butlerd.InstallPerformResult, InstallPerformStrategy, error) {
 	isub := &InstallSubcontext{
 		Data: &InstallSubcontextState{},
 	}
@@ -131,13 +150,13 @@ func doInstallPerform(oc *OperationContext, meta *MetaSubcontext) (*butlerd.Inst
 		Type: hush.InstallEventResume,
 	})
 	if err != nil {
-		return nil, err
+		return nil, InstallPerformStrategyNone, err
 	}
 
-	err = doInstallPerformInner(oc, meta, isub)
+	strategy, err := doInstallPerformInner(oc, meta, isub)
 	if err != nil {
 		_ = isub.EventSink(oc).PostProblem(err)
-		return nil, err
+		return nil, strategy, err
 	}
 
 	var caveID string
@@ -149,17 +168,17 @@ func doInstallPerform(oc *OperationContext, meta *MetaSubcontext) (*butlerd.Inst
 		CaveID: caveID,
 		Events: isub.Data.Events,
 	}
-	return res, nil
+	return res, strategy, nil
 }
 
-func doInstallPerformInner(oc *OperationContext, meta *MetaSubcontext, isub *InstallSubcontext) error {
+func doInstallPerformInner(oc *OperationContext, meta *MetaSubcontext, isub *InstallSubcontext) (InstallPerformStrategy, error) {
 	rc := oc.rc
 	params := meta.Data
 	consumer := oc.Consumer()
 	istate := isub.Data
 
 	if params.Game == nil {
-		return errors.Errorf("Corrupted download info (missing game), refusing to continue.")
+		return InstallPerformStrategyNone, errors.Errorf("Corrupted download info (missing game), refusing to continue.")
 	}
 
 	{
@@ -168,7 +187,7 @@ func doInstallPerformInner(oc *OperationContext, meta *MetaSubcontext, isub *Ins
 			istate.RefreshedGame = true
 			err := oc.Save(isub)
 			if err != nil {
-				return err
+				return InstallPerformStrategyNone, err
 			}
 
 			// attempt to refresh game info
@@ -182,7 +201,7 @@ func doInstallPerformInner(oc *OperationContext, meta *MetaSubcontext, isub *Ins
 				params.Game = gameRes.Game
 				err = oc.Save(meta)
 				if err != nil {
-					return err
+					return InstallPerformStrategyNone, err
 				}
 			}
 		}
@@ -195,11 +214,21 @@ func doInstallPerformInner(oc *OperationContext, meta *MetaSubcontext, isub *Ins
 	rlock := runlock.New(consumer, params.InstallFolder)
 	err := rlock.Lock(rc.Ctx, "install")
 	if err != nil {
-		return errors.WithStack(err)
+		return InstallPerformStrategyNone, errors.WithStack(err)
 	}
 	defer rlock.Unlock()
 
-	return InstallPrepare(oc, meta, isub, true, func(prepareRes *InstallPrepareResult) error {
+	strategy := InstallPerformStrategyNone
+	// upgrades that fall back to healing are still updates
+	reason := butlerd.TaskReasonInstall
+	// set if the archive should be cached once installed
+	var stagedArchivePath string
+	err = InstallPrepare(oc, meta, isub, true, func(prepareRes *InstallPrepareResult) error {
+		strategy = prepareRes.Strategy
+		if strategy == InstallPerformStrategyUpgrade {
+			reason = butlerd.TaskReasonUpdate
+		}
+
 		if !params.NoCave {
 			var cave *models.Cave
 			rc.WithConn(func(conn *sqlite.Conn) {
@@ -245,11 +274,28 @@ func doInstallPerformInner(oc *OperationContext, meta *MetaSubcontext, isub *Ins
 			if err != nil {
 				return err
 			}
+			recordInstallOutcome(strategy, upgradeErr)
 			prepareRes.Strategy = InstallPerformStrategyHeal
+			strategy = prepareRes.Strategy
 		}
 
 		if prepareRes.Strategy == InstallPerformStrategyHeal {
-			return heal(oc, meta, isub, prepareRes.ReceiptIn)
+			return heal(oc, meta, isub, prepareRes.ReceiptIn, nil)
+		}
+
+		if istate.BuildCachePath != "" && (istate.FirstInstallResult == nil || istate.IsAvailableLocally) {
+			// download the archive to the staging folder first, so it
+			// can be moved to the cache once installed
+			remoteStats, err := prepareRes.File.Stat()
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			lf, err := doForceLocal(prepareRes.File, oc, meta, isub)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			stagedArchivePath = localSourcePath(oc, remoteStats)
+			prepareRes.File = lf
 		}
 
 		stats, err := prepareRes.File.Stat()
@@ -369,4 +415,21 @@ func doInstallPerformInner(oc *OperationContext, meta *MetaSubcontext, isub *Ins
 		})
 
 	})
+	if err == nil {
+		if istate.UsedMirror {
+			// heals can use a mirrored archive too
+			consumer.Infof("Build files came from a mirror or cache, verifying install against itch.io...")
+			err = verifyInstall(oc, meta, isub, reason)
+		} else if (strategy == InstallPerformStrategyInstall || strategy == InstallPerformStrategyUpgrade) && shouldVerifyInstall(oc, meta) {
+			err = verifyInstall(oc, meta, isub, reason)
+		}
+	}
+	if err == nil && stagedArchivePath != "" {
+		cacheErr := cacheBuildFile(oc, stagedArchivePath, istate.BuildCachePath)
+		if cacheErr != nil {
+			consumer.Warnf("Could not cache build file: %+v", cacheErr)
+		}
+	}
+	recordInstallOutcome(strategy, err)
+	return strategy, err
 }
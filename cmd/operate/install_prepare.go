@@ -10,7 +10,6 @@ import (
 	itchio "github.com/itchio/go-itchio"
 	"github.com/itchio/headway/united"
 	"github.com/itchio/httpkit/eos"
-	"github.com/itchio/httpkit/eos/option"
 	"github.com/pkg/errors"
 )
 
@@ -73,6 +72,20 @@ func InstallPrepare(oc *OperationContext, meta *MetaSubcontext, isub *InstallSub
 		consumer.Infof("↻ Resuming download session (%s)", istate.DownloadSessionID)
 	}
 
+	// proceed reserves disk space before anything is downloaded,
+	// then hands off to task.
+	proceed := func(strategy InstallPerformStrategy, neededFreeSpace int64) error {
+		err := reserveDiskSpace(oc, meta, isub, neededFreeSpace)
+		if err != nil {
+			return err
+		}
+		res.Strategy = strategy
+		return task(res)
+	}
+
+	// heals may end up downloading the whole upload
+	healFreeSpace := params.Upload.Size
+
 	if receiptIn == nil {
 		consumer.Infof("← No previous install info (no recorded upload or build)")
 	} else {
@@ -92,8 +105,7 @@ func InstallPrepare(oc *OperationContext, meta *MetaSubcontext, isub *InstallSub
 				consumer.Infof("↑ Upgrading from build %d to %d", oldID, newID)
 				if istate.UsingHealFallback {
 					consumer.Infof("Using heal fallback (as specified in install state)")
-					res.Strategy = InstallPerformStrategyHeal
-					return task(res)
+					return proceed(InstallPerformStrategyHeal, healFreeSpace)
 				}
 
 				upgradeRes, err := client.GetBuildUpgradePath(rc.Ctx, itchio.GetBuildUpgradePathParams{
@@ -104,8 +116,7 @@ func InstallPrepare(oc *OperationContext, meta *MetaSubcontext, isub *InstallSub
 				if err != nil {
 					consumer.Warnf("Could not find upgrade path: %s", err.Error())
 					consumer.Infof("Falling back to heal...")
-					res.Strategy = InstallPerformStrategyHeal
-					return task(res)
+					return proceed(InstallPerformStrategyHeal, healFreeSpace)
 				}
 
 				upgradePath := upgradeRes.UpgradePath
@@ -119,8 +130,7 @@ func InstallPrepare(oc *OperationContext, meta *MetaSubcontext, isub *InstallSub
 					f := FindBuildFile(b.Files, itchio.BuildFileTypePatch, itchio.BuildFileSubTypeDefault)
 					if f == nil {
 						consumer.Warnf("Whoops, build %d is missing a patch, falling back to heal...", b.ID)
-						res.Strategy = InstallPerformStrategyHeal
-						return task(res)
+						return proceed(InstallPerformStrategyHeal, healFreeSpace)
 					}
 
 					{
@@ -147,12 +157,10 @@ func InstallPrepare(oc *OperationContext, meta *MetaSubcontext, isub *InstallSub
 
 				if totalUpgradeSize > fullUploadSize {
 					consumer.Infof("Heal is less expensive, let's do that")
-					res.Strategy = InstallPerformStrategyHeal
-					return task(res)
+					return proceed(InstallPerformStrategyHeal, healFreeSpace)
 				}
 
 				consumer.Infof("Will apply %d patches", len(upgradePath.Builds))
-				res.Strategy = InstallPerformStrategyUpgrade
 
 				if istate.UpgradePath == nil {
 					istate.UpgradePath = upgradePath
@@ -165,16 +173,16 @@ func InstallPrepare(oc *OperationContext, meta *MetaSubcontext, isub *InstallSub
 					consumer.Infof("%d patches already done, letting it resume", istate.UpgradePathIndex)
 				}
 
-				return task(res)
+				// patched files are written next to the old ones before
+				// replacing them, so patches are a fair estimate
+				return proceed(InstallPerformStrategyUpgrade, totalUpgradeSize)
 			} else if newID < oldID {
 				consumer.Infof("↓ Downgrading from build %d to %d", oldID, newID)
-				res.Strategy = InstallPerformStrategyHeal
-				return task(res)
+				return proceed(InstallPerformStrategyHeal, healFreeSpace)
 			}
 
 			consumer.Infof("↺ Re-installing build %d", newID)
-			res.Strategy = InstallPerformStrategyHeal
-			return task(res)
+			return proceed(InstallPerformStrategyHeal, healFreeSpace)
 		}
 	}
 
@@ -183,9 +191,30 @@ func InstallPrepare(oc *OperationContext, meta *MetaSubcontext, isub *InstallSub
 		installSourceFileType = "archive"
 	}
 	installSourceURL := MakeSourceURL(client, consumer, istate.DownloadSessionID, params, installSourceFileType)
+	if allowDownloads && params.Build != nil {
+		fileType := installSourceFileType
+		if fileType == "" {
+			fileType = "archive"
+		}
+		src := findBuildFileSource(oc, client, params.Build, itchio.MakeBuildDownloadURLParams{
+			BuildID:     params.Build.ID,
+			UUID:        istate.DownloadSessionID,
+			Credentials: params.Access.Credentials,
+			Type:        itchio.BuildFileType(fileType),
+		})
+		if src.MirroredURL != "" {
+			installSourceURL = src.MirroredURL
+			istate.UsedMirror = true
+		}
+		istate.BuildCachePath = src.CachePath
+		err = oc.Save(isub)
+		if err != nil {
+			return err
+		}
+	}
 
 	beforeOpen := time.Now()
-	file, err := eos.Open(installSourceURL, option.WithConsumer(consumer))
+	file, err := eos.Open(installSourceURL, oc.EOSOptions()...)
 	consumer.Infof("(opening file took %s)", time.Since(beforeOpen))
 	if err != nil {
 		return errors.WithStack(err)
@@ -253,6 +282,7 @@ func InstallPrepare(oc *OperationContext, meta *MetaSubcontext, isub *InstallSub
 		consumer.Infof("  ✓ %s final disk usage", united.FormatBytes(dui.FinalDiskUsage))
 
 		istate.InstallerInfo = installerInfo
+		istate.NeededFreeSpace = dui.NeededFreeSpace
 		err = oc.Save(isub)
 		if err != nil {
 			return err
@@ -261,5 +291,15 @@ func InstallPrepare(oc *OperationContext, meta *MetaSubcontext, isub *InstallSub
 		consumer.Infof("Using cached source information")
 	}
 
-	return task(res)
+	neededFreeSpace := istate.NeededFreeSpace
+	if istate.BuildCachePath != "" {
+		// the archive is downloaded to the staging folder before
+		// it's cached, see doInstallPerformInner
+		stats, err := file.Stat()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		neededFreeSpace += stats.Size()
+	}
+	return proceed(InstallPerformStrategyInstall, neededFreeSpace)
 }
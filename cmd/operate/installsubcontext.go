@@ -15,6 +15,10 @@ type InstallSubcontextState struct {
 	UpgradePathIndex    int                 `json:"upgradePathIndex,omitempty"`
 	UsingHealFallback   bool                `json:"usingHealFallback,omitempty"`
 	RefreshedGame       bool                `json:"refreshedGame,omitempty"`
+	UsedMirror          bool                `json:"usedMirror,omitempty"`
+	BuildCachePath      string              `json:"buildCachePath,omitempty"`
+	NeededFreeSpace     int64               `json:"neededFreeSpace,omitempty"`
+	ReservedSpace       int64               `json:"reservedSpace,omitempty"`
 
 	Events []hush.InstallEvent
 }
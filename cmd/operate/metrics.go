@@ -0,0 +1,49 @@
+package operate
+
+import (
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/metrics"
+	"github.com/itchio/wharf/pwr/patcher"
+	"github.com/itchio/wharf/werrors"
+	"github.com/pkg/errors"
+)
+
+var installsTotal = metrics.Default.NewCounter("butlerd_installs_total",
+	"Install operations performed, by strategy (install, upgrade, heal) and outcome (success, failure, cancelled)",
+	"strategy", "outcome")
+
+func recordInstallOutcome(strategy InstallPerformStrategy, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+		if isCancellation(err) {
+			outcome = "cancelled"
+		}
+	}
+	installsTotal.Inc(strategyName(strategy), outcome)
+}
+
+func isCancellation(err error) bool {
+	cause := errors.Cause(err)
+	if cause == werrors.ErrCancelled || cause == patcher.ErrStop {
+		return true
+	}
+	if be, ok := butlerd.AsButlerdError(err); ok {
+		return be.RpcErrorCode() == int64(butlerd.CodeOperationCancelled)
+	}
+	return false
+}
+
+// strategyName returns a short, human-readable name for an install strategy
+func strategyName(strategy InstallPerformStrategy) string {
+	switch strategy {
+	case InstallPerformStrategyInstall:
+		return "install"
+	case InstallPerformStrategyUpgrade:
+		return "upgrade"
+	case InstallPerformStrategyHeal:
+		return "heal"
+	default:
+		return "none"
+	}
+}
@@ -0,0 +1,127 @@
+package operate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"crawshaw.io/sqlite"
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/cmd/sizeof"
+	"github.com/itchio/butler/database/models"
+	"github.com/itchio/butler/endpoints/system"
+	"github.com/itchio/hades"
+	"github.com/itchio/headway/state"
+	"github.com/itchio/headway/united"
+	"github.com/pkg/errors"
+	"xorm.io/builder"
+)
+
+// reserveLock makes checking for free space and reserving it atomic,
+// so that concurrent downloads don't all count the same free space.
+var reserveLock sync.Mutex
+
+// reserveDiskSpace records how much free space the install needs, so that
+// Downloads.Queue can reserve it, and reserves it right away if the install
+// already has a download.
+func reserveDiskSpace(oc *OperationContext, meta *MetaSubcontext, isub *InstallSubcontext, neededFreeSpace int64) error {
+	istate := isub.Data
+	if istate.ReservedSpace != neededFreeSpace {
+		istate.ReservedSpace = neededFreeSpace
+		err := oc.Save(isub)
+		if err != nil {
+			return err
+		}
+	}
+
+	var err error
+	oc.rc.WithConn(func(conn *sqlite.Conn) {
+		err = ReserveDiskSpace(conn, oc.Consumer(), meta.Data.InstallLocationID, meta.Data.CaveID, oc.StageFolder(), neededFreeSpace)
+	})
+	return err
+}
+
+// ReserveDiskSpace makes sure the install location has enough free space
+// for the download using stagingFolder, on top of the space reserved by
+// other unfinished downloads, and reserves it for that download. Downloads
+// for caveID are about to be replaced, so their reservation doesn't count.
+//
+// The reservation is released when the download finishes or is discarded.
+func ReserveDiskSpace(conn *sqlite.Conn, consumer *state.Consumer, installLocationID string, caveID string, stagingFolder string, neededFreeSpace int64) error {
+	if neededFreeSpace <= 0 || installLocationID == "" {
+		// nothing to reserve, or nowhere to reserve it
+		return nil
+	}
+
+	reserveLock.Lock()
+	defer reserveLock.Unlock()
+
+	il := models.InstallLocationByID(conn, installLocationID)
+	if il == nil {
+		return errors.Errorf("Install location %s not found", installLocationID)
+	}
+
+	var reservedByOthers int64
+	for _, d := range models.DownloadsReservingSpace(conn, installLocationID, stagingFolder, caveID) {
+		// space used by downloads in progress already shows up as used,
+		// only what they have left to write is still reserved
+		remaining := d.ReservedSpace - writtenSpace(d)
+		if remaining > 0 {
+			reservedByOthers += remaining
+		}
+	}
+
+	stats, err := system.StatFS(il.Path)
+	if err != nil {
+		consumer.Warnf("Could not statFS (%s), skipping free space check: %s", il.Path, err.Error())
+		return nil
+	}
+
+	available := stats.FreeSize - reservedByOthers
+	consumer.Infof("  ✓ %s free, %s reserved by other downloads", united.FormatBytes(stats.FreeSize), united.FormatBytes(reservedByOthers))
+	if available < neededFreeSpace {
+		return errors.WithMessage(butlerd.CodeInsufficientDiskSpace, fmt.Sprintf("need %s, only %s available in (%s)",
+			united.FormatBytes(neededFreeSpace), united.FormatBytes(available), il.Path))
+	}
+
+	models.MustUpdate(conn, &models.Download{},
+		hades.Where(builder.Eq{"staging_folder": stagingFolder}),
+		builder.Eq{"reserved_space": neededFreeSpace},
+	)
+	return nil
+}
+
+// writtenSpace returns how much a download in progress has written so far:
+// everything in its staging folder, and in its install folder if it's
+// not installing over an existing one.
+func writtenSpace(d *models.Download) int64 {
+	written, _ := sizeof.Do(d.StagingFolder)
+	if d.Fresh {
+		installed, _ := sizeof.Do(d.InstallFolder)
+		written += installed
+	}
+	return written
+}
+
+// PlannedDiskSpace returns how much free space InstallPrepare found the
+// install using stagingFolder needs, or 0 if it hasn't been prepared yet,
+// as is the case for fast-queued installs.
+func PlannedDiskSpace(stagingFolder string) int64 {
+	f, err := os.Open(contextPath(stagingFolder))
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	var root struct {
+		Install struct {
+			ReservedSpace int64 `json:"reservedSpace"`
+		} `json:"install"`
+	}
+	err = json.NewDecoder(f).Decode(&root)
+	if err != nil {
+		return 0
+	}
+	return root.Install.ReservedSpace
+}
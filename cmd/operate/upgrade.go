@@ -20,8 +20,6 @@ import (
 
 	"github.com/itchio/savior/filesource"
 
-	"github.com/itchio/httpkit/eos/option"
-
 	"github.com/itchio/lake/pools/fspool"
 
 	"github.com/itchio/wharf/pwr"
@@ -123,22 +121,34 @@ func applyPatch(oc *OperationContext, meta *MetaSubcontext, isub *InstallSubcont
 		subType = itchio.BuildFileSubTypeOptimized
 	}
 
-	patchURL := client.MakeBuildDownloadURL(itchio.MakeBuildDownloadURLParams{
+	patchParams := itchio.MakeBuildDownloadURLParams{
 		Credentials: params.Access.Credentials,
 		BuildID:     build.ID,
 		Type:        itchio.BuildFileTypePatch,
 		SubType:     subType,
 		UUID:        istate.DownloadSessionID,
-	})
+	}
+	patchURL, mirrored := fetchBuildFile(oc, client, build, patchParams)
+	if mirrored {
+		istate.UsedMirror = true
+	}
 
-	parentSignatureURL := client.MakeBuildDownloadURL(itchio.MakeBuildDownloadURLParams{
+	parentSignatureParams := itchio.MakeBuildDownloadURLParams{
 		Credentials: params.Access.Credentials,
 		BuildID:     build.ParentBuildID,
 		Type:        itchio.BuildFileTypeSignature,
 		UUID:        istate.DownloadSessionID,
-	})
+	}
+	parentSignatureURL, mirrored := fetchBuildFile(oc, client, &itchio.Build{ID: build.ParentBuildID}, parentSignatureParams)
+	if mirrored {
+		istate.UsedMirror = true
+	}
+	err := oc.Save(isub)
+	if err != nil {
+		return err
+	}
 
-	patchSource, err := filesource.Open(patchURL, option.WithConsumer(consumer))
+	patchSource, err := filesource.Open(patchURL, oc.EOSOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "opening remote patch")
 	}
@@ -200,7 +210,7 @@ func applyPatch(oc *OperationContext, meta *MetaSubcontext, isub *InstallSubcont
 	targetPool, err := pwr.NewSafeKeeper(pwr.SafeKeeperParams{
 		Inner: fspool.New(p.GetTargetContainer(), params.InstallFolder),
 		Open: func() (savior.SeekSource, error) {
-			return filesource.Open(parentSignatureURL, option.WithConsumer(consumer))
+			return filesource.Open(parentSignatureURL, oc.EOSOptions()...)
 		},
 	})
 	if err != nil {
@@ -0,0 +1,274 @@
+package operate
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"crawshaw.io/sqlite"
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/messages"
+	"github.com/itchio/butler/database/models"
+	"github.com/itchio/headway/united"
+	"github.com/itchio/hush/bfs"
+	"github.com/itchio/lake/tlc"
+	"github.com/itchio/savior/seeksource"
+	"github.com/itchio/wharf/pwr"
+	"github.com/itchio/wharf/wire"
+	"github.com/pkg/errors"
+)
+
+var verifyAllInstalls bool
+var verifyLock sync.Mutex
+
+// SetVerifyAllInstalls makes butler verify installs to every install
+// location, not just those that have VerifyInstalls set.
+func SetVerifyAllInstalls(enabled bool) {
+	verifyLock.Lock()
+	defer verifyLock.Unlock()
+	verifyAllInstalls = enabled
+}
+
+func shouldVerifyInstall(oc *OperationContext, meta *MetaSubcontext) bool {
+	verifyLock.Lock()
+	all := verifyAllInstalls
+	verifyLock.Unlock()
+	if all {
+		return true
+	}
+
+	params := meta.Data
+	if params.InstallLocationID == "" {
+		return false
+	}
+
+	var il *models.InstallLocation
+	oc.rc.WithConn(func(conn *sqlite.Conn) {
+		il = models.InstallLocationByID(conn, params.InstallLocationID)
+	})
+	return il != nil && il.VerifyInstalls
+}
+
+// verifyInstall checks the install folder against the build's signature
+// from itch.io. Anything missing or corrupted is reported, then healed.
+func verifyInstall(oc *OperationContext, meta *MetaSubcontext, isub *InstallSubcontext, reason butlerd.TaskReason) error {
+	consumer := oc.Consumer()
+	params := meta.Data
+
+	if params.Build == nil {
+		consumer.Infof("Upload is not wharf-enabled, can't verify install")
+		return nil
+	}
+
+	err := messages.TaskStarted.Notify(oc.rc, butlerd.TaskStartedNotification{
+		Reason: reason,
+		Type:   butlerd.TaskTypeVerify,
+		Game:   params.Game,
+		Upload: params.Upload,
+		Build:  params.Build,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	sigInfo, err := fetchSignature(oc, meta, isub)
+	if err != nil {
+		return err
+	}
+
+	woundsPath := filepath.Join(oc.StageFolder(), "verify.pww")
+	// the wounds file is only written if there are wounds,
+	// don't pick up one from a previous attempt
+	os.Remove(woundsPath)
+
+	vc := &pwr.ValidatorContext{
+		Consumer:   consumer,
+		WoundsPath: woundsPath,
+	}
+
+	consumer.Infof("Verifying install...")
+	timeBeforeVerify := time.Now()
+
+	oc.rc.StartProgress()
+	err = vc.Validate(oc.ctx, params.InstallFolder, sigInfo)
+	oc.rc.EndProgress()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	verifyDuration := time.Since(timeBeforeVerify)
+	containerSize := sigInfo.Container.Size
+
+	if isub.Data.UsedMirror {
+		// the validator only looks at files the build has
+		err = removeUntrackedFiles(oc, params.InstallFolder, sigInfo.Container)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = messages.TaskSucceeded.Notify(oc.rc, butlerd.TaskSucceededNotification{
+		Type: butlerd.TaskTypeVerify,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if !vc.WoundsConsumer.HasWounds() {
+		consumer.Infof("✓ All %s were healthy (checked @ %s, %s total)",
+			united.FormatBytes(containerSize),
+			united.FormatBPS(containerSize, verifyDuration),
+			verifyDuration,
+		)
+		return nil
+	}
+
+	wounds, err := readWounds(woundsPath)
+	if err != nil {
+		return err
+	}
+	paths := woundedPaths(sigInfo.Container, wounds)
+
+	consumer.Warnf("%s corrupted data found (of %s total), %d paths affected",
+		united.FormatBytes(vc.WoundsConsumer.TotalCorrupted()),
+		united.FormatBytes(containerSize),
+		len(paths),
+	)
+
+	err = messages.InstallWounds.Notify(oc.rc, butlerd.InstallWoundsNotification{
+		Game:           params.Game,
+		Upload:         params.Upload,
+		Build:          params.Build,
+		Paths:          paths,
+		TotalCorrupted: vc.WoundsConsumer.TotalCorrupted(),
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	consumer.Infof("Healing from itch.io...")
+	receiptIn, err := bfs.ReadReceipt(params.InstallFolder)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return heal(oc, meta, isub, receiptIn, &knownWounds{
+		SigInfo: sigInfo,
+		Wounds:  wounds,
+	})
+}
+
+// removeUntrackedFiles removes files the install wrote that aren't part
+// of the build, as could happen with an archive or patch from a mirror that
+// has more in it than it should, and drops them from the receipt.
+// Other files, like saves or mods, are left alone.
+func removeUntrackedFiles(oc *OperationContext, installFolder string, container *tlc.Container) error {
+	consumer := oc.Consumer()
+
+	receipt, err := bfs.ReadReceipt(installFolder)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if !receipt.HasFiles() {
+		return nil
+	}
+
+	tracked := make(map[string]bool)
+	for _, f := range resultForContainer(container).Files {
+		tracked[filepath.ToSlash(filepath.Clean(f))] = true
+	}
+
+	var kept []string
+	for _, f := range receipt.Files {
+		if tracked[filepath.ToSlash(filepath.Clean(f))] {
+			kept = append(kept, f)
+			continue
+		}
+
+		consumer.Warnf("Removing (%s), it's not part of the build", f)
+		err := os.Remove(filepath.Join(installFolder, filepath.FromSlash(f)))
+		if err != nil && !os.IsNotExist(err) {
+			return errors.WithStack(err)
+		}
+	}
+
+	if len(kept) == len(receipt.Files) {
+		return nil
+	}
+	receipt.Files = kept
+	return errors.WithStack(receipt.WriteReceipt(installFolder))
+}
+
+// readWounds returns every wound in a wounds file, in order.
+func readWounds(woundsPath string) ([]*pwr.Wound, error) {
+	reader, err := os.Open(woundsPath)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer reader.Close()
+
+	source := seeksource.FromFile(reader)
+	_, err = source.Resume(nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	rctx := wire.NewReadContext(source)
+	err = rctx.ExpectMagic(pwr.WoundsMagic)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading wounds magic")
+	}
+
+	wh := &pwr.WoundsHeader{}
+	err = rctx.ReadMessage(wh)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading wounds header")
+	}
+
+	container := &tlc.Container{}
+	err = rctx.ReadMessage(container)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading container from wounds file")
+	}
+
+	var wounds []*pwr.Wound
+	for {
+		wound := &pwr.Wound{}
+		err = rctx.ReadMessage(wound)
+		if err != nil {
+			if errors.Cause(err) == io.EOF {
+				break
+			}
+			return nil, errors.Wrap(err, "reading wound")
+		}
+		wounds = append(wounds, wound)
+	}
+	return wounds, nil
+}
+
+// woundedPaths returns the path of every file, folder or symlink
+// of container that has at least one wound, in order.
+func woundedPaths(container *tlc.Container, wounds []*pwr.Wound) []string {
+	paths := []string{}
+	seen := make(map[string]bool)
+	for _, wound := range wounds {
+		var path string
+		switch wound.Kind {
+		case pwr.WoundKind_FILE:
+			path = container.Files[wound.Index].Path
+		case pwr.WoundKind_DIR:
+			path = container.Dirs[wound.Index].Path
+		case pwr.WoundKind_SYMLINK:
+			path = container.Symlinks[wound.Index].Path
+		default:
+			continue
+		}
+
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/itchio/butler/cmd/diff"
 	"github.com/itchio/butler/cmd/ditto"
 	"github.com/itchio/butler/cmd/dl"
+	"github.com/itchio/butler/cmd/downloads"
 	"github.com/itchio/butler/cmd/elevate"
 	"github.com/itchio/butler/cmd/elfprops"
 	"github.com/itchio/butler/cmd/exeprops"
@@ -19,6 +20,7 @@ import (
 	"github.com/itchio/butler/cmd/file"
 	"github.com/itchio/butler/cmd/fujicmd"
 	"github.com/itchio/butler/cmd/heal"
+	"github.com/itchio/butler/cmd/launch"
 	"github.com/itchio/butler/cmd/login"
 	"github.com/itchio/butler/cmd/logout"
 	"github.com/itchio/butler/cmd/ls"
@@ -119,4 +121,6 @@ func registerCommands(ctx *mansion.Context) {
 
 	ratetest.Register(ctx)
 	diag.Register(ctx)
+	downloads.Register(ctx)
+	launch.Register(ctx)
 }
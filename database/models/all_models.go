@@ -26,4 +26,9 @@ var AllModels = []interface{}{
 	&FetchInfo{},
 	&GameUpload{},
 	&CaveHistoricalPlayTime{},
+	&BackgroundTask{},
+	&DownloadSchedule{},
+	&DownloadHistoryEntry{},
+	&LaunchSession{},
+	&CustomHost{},
 }
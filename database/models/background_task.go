@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"crawshaw.io/sqlite"
+	"github.com/itchio/hades"
+	"xorm.io/builder"
+)
+
+// A BackgroundTask is persisted until it succeeds (or is cancelled),
+// so that it can be resumed when butlerd restarts.
+type BackgroundTask struct {
+	// An UUID
+	ID string `hades:"primary_key"`
+
+	// Which handler the task should be run with
+	Kind string
+	// JSON-encoded parameters for the handler
+	Params string
+	Desc   string
+
+	// Number of failed attempts so far
+	Attempts  int64
+	LastError *string
+
+	QueuedAt      *time.Time
+	NextAttemptAt *time.Time
+	// Set when the task failed too many times and won't be retried
+	GaveUpAt *time.Time
+}
+
+func AllBackgroundTasks(conn *sqlite.Conn) []*BackgroundTask {
+	var tasks []*BackgroundTask
+	MustSelect(conn, &tasks, builder.NewCond(), hades.Search{}.OrderBy("queued_at ASC"))
+	return tasks
+}
+
+// PendingBackgroundTasks returns tasks that haven't been given up on.
+func PendingBackgroundTasks(conn *sqlite.Conn) []*BackgroundTask {
+	var tasks []*BackgroundTask
+	MustSelect(conn, &tasks, builder.IsNull{"gave_up_at"}, hades.Search{}.OrderBy("queued_at ASC"))
+	return tasks
+}
+
+func BackgroundTaskByID(conn *sqlite.Conn, taskID string) *BackgroundTask {
+	var task BackgroundTask
+	if MustSelectOne(conn, &task, builder.Eq{"id": taskID}) {
+		return &task
+	}
+	return nil
+}
+
+// PendingBackgroundTaskLike returns a task of the same kind and with the
+// same parameters, if one is pending.
+func PendingBackgroundTaskLike(conn *sqlite.Conn, kind string, params string) *BackgroundTask {
+	var task BackgroundTask
+	if MustSelectOne(conn, &task, builder.And(
+		builder.Eq{"kind": kind, "params": params},
+		builder.IsNull{"gave_up_at"},
+	)) {
+		return &task
+	}
+	return nil
+}
+
+func (bt *BackgroundTask) Save(conn *sqlite.Conn) {
+	MustSave(conn, bt)
+}
+
+func DeleteBackgroundTask(conn *sqlite.Conn, taskID string) {
+	MustDelete(conn, &BackgroundTask{}, builder.Eq{"id": taskID})
+}
@@ -43,6 +43,9 @@ type Cave struct {
 	// If set, InstallLocationID is empty and this is used
 	// for all operations instead
 	CustomInstallFolder string `json:"customInstallFolder"`
+
+	// JSON-encoded butlerd.LaunchOverrides, empty if there are none
+	LaunchOverrides JSON `json:"launchOverrides"`
 }
 
 func (c *Cave) SetVerdict(verdict *dash.Verdict) {
@@ -95,6 +98,22 @@ func (c *Cave) UpdateInteractions(summary *itchio.UserGameInteractionsSummary) {
 	}
 }
 
+// SaveInteractions only writes play time, so it doesn't overwrite
+// changes made to the cave since it was loaded, like launch overrides
+func (c *Cave) SaveInteractions(conn *sqlite.Conn) {
+	var lastTouchedAt interface{}
+	if c.LastTouchedAt != nil {
+		lastTouchedAt = c.LastTouchedAt.Format(time.RFC3339Nano)
+	}
+	MustUpdate(conn, &Cave{},
+		hades.Where(builder.Eq{"id": c.ID}),
+		builder.Eq{
+			"seconds_run":     c.SecondsRun,
+			"last_touched_at": lastTouchedAt,
+		},
+	)
+}
+
 func (c *Cave) GetInstallLocation(conn *sqlite.Conn) *InstallLocation {
 	if c.InstallLocation != nil {
 		return c.InstallLocation
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"crawshaw.io/sqlite"
+	"github.com/itchio/hades"
+	"xorm.io/builder"
+)
+
+// A CustomHost is a wrapper host (Proton, box64, DOSBox, etc.) declared
+// by the user, considered along with the ones butler finds by itself.
+type CustomHost struct {
+	// An UUID
+	ID string `json:"id" hades:"primary_key"`
+
+	// Human-readable, like "Proton 8 (default prefix)"
+	Name string `json:"name"`
+
+	// JSON-encoded manager.Host, see butlerd.DecodeCustomHost
+	Host JSON `json:"host"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func CustomHostByID(conn *sqlite.Conn, id string) *CustomHost {
+	var ch CustomHost
+	if MustSelectOne(conn, &ch, builder.Eq{"id": id}) {
+		return &ch
+	}
+	return nil
+}
+
+// AllCustomHosts returns custom hosts, oldest first
+func AllCustomHosts(conn *sqlite.Conn) []*CustomHost {
+	var hosts []*CustomHost
+	MustSelect(conn, &hosts, builder.NewCond(), hades.Search{}.OrderBy("created_at ASC"))
+	return hosts
+}
+
+func (ch *CustomHost) Save(conn *sqlite.Conn) {
+	MustSave(conn, ch)
+}
+
+func (ch *CustomHost) Delete(conn *sqlite.Conn) {
+	MustDelete(conn, &CustomHost{}, builder.Eq{"id": ch.ID})
+}
@@ -45,6 +45,18 @@ type Download struct {
 
 	Discarded bool `json:"discarded"`
 	Fresh     bool `json:"fresh"`
+
+	// Paused downloads aren't performed, but keep their staging folder
+	Paused bool `json:"paused"`
+
+	// Number of attempts that failed with a transient error
+	Attempts int64 `json:"attempts"`
+	// Downloads aren't retried before that
+	NextAttemptAt *time.Time `json:"nextAttemptAt"`
+
+	// Free space needed to perform the download, in bytes, as
+	// estimated when it started. Set aside until it's finished.
+	ReservedSpace int64 `json:"reservedSpace"`
 }
 
 func AllDownloads(conn *sqlite.Conn) []*Download {
@@ -94,6 +106,26 @@ func downloadExtremePosition(conn *sqlite.Conn, extreme string) int64 {
 	return position
 }
 
+// DownloadsReservingSpace returns unfinished downloads that have space set
+// aside in an install location, except for the one using exceptStagingFolder,
+// and those for exceptCaveID, if any, which are about to be replaced.
+func DownloadsReservingSpace(conn *sqlite.Conn, installLocationID string, exceptStagingFolder string, exceptCaveID string) []*Download {
+	cond := builder.And(
+		builder.Eq{"install_location_id": installLocationID},
+		builder.IsNull{"finished_at"},
+		builder.Not{builder.Expr("discarded")},
+		builder.Neq{"staging_folder": exceptStagingFolder},
+		builder.Gt{"reserved_space": 0},
+	)
+	if exceptCaveID != "" {
+		cond = cond.And(builder.Neq{"cave_id": exceptCaveID})
+	}
+
+	var ds []*Download
+	MustSelect(conn, &ds, cond, hades.Search{})
+	return ds
+}
+
 func (d *Download) Save(conn *sqlite.Conn) {
 	MustSave(conn, d)
 }
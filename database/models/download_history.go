@@ -0,0 +1,94 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"crawshaw.io/sqlite"
+	itchio "github.com/itchio/go-itchio"
+	"github.com/itchio/hades"
+	"github.com/pkg/errors"
+)
+
+// A DownloadHistoryEntry is recorded every time the downloads drive
+// finishes performing a download, whether it succeeded, failed, or
+// failed and will be retried. Stopped or cancelled downloads aren't
+// recorded.
+type DownloadHistoryEntry struct {
+	// An UUID
+	ID string `json:"id" hades:"primary_key"`
+
+	// The download this entry is about. It may have been cleared since.
+	DownloadID string `json:"downloadId"`
+	CaveID     string `json:"caveId"`
+
+	GameID int64        `json:"gameId"`
+	Game   *itchio.Game `json:"game"`
+
+	UploadID int64          `json:"uploadId"`
+	Upload   *itchio.Upload `json:"upload"`
+
+	BuildID int64         `json:"buildId"`
+	Build   *itchio.Build `json:"build"`
+
+	Reason string `json:"reason"`
+	// "install", "upgrade", "heal", or "none" if it failed before
+	// picking one
+	Strategy string `json:"strategy"`
+
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	// In seconds
+	WallTime float64 `json:"wallTime"`
+
+	// Estimated from speed samples, in bytes
+	BytesTransferred int64 `json:"bytesTransferred"`
+	// In bytes per second
+	AverageBPS float64 `json:"averageBps"`
+	// JSON-encoded []float64, the last speed samples sent with
+	// Downloads.Drive.Progress
+	SpeedHistory JSON `json:"speedHistory"`
+
+	// Standard butlerd error code, if the download failed
+	ErrorCode *int64 `json:"errorCode"`
+	// Short error message, if the download failed
+	ErrorMessage *string `json:"errorMessage"`
+}
+
+func (e *DownloadHistoryEntry) Save(conn *sqlite.Conn) {
+	MustSave(conn, e)
+}
+
+func (e *DownloadHistoryEntry) GetSpeedHistory() ([]float64, error) {
+	var samples []float64
+	if e.SpeedHistory == "" {
+		return nil, nil
+	}
+
+	err := json.Unmarshal([]byte(e.SpeedHistory), &samples)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshalling speed history")
+	}
+	return samples, nil
+}
+
+func (e *DownloadHistoryEntry) SetSpeedHistory(samples []float64) error {
+	if samples == nil {
+		samples = []float64{}
+	}
+
+	contents, err := json.Marshal(samples)
+	if err != nil {
+		return errors.Wrap(err, "marshalling speed history")
+	}
+	e.SpeedHistory = JSON(contents)
+	return nil
+}
+
+func PreloadDownloadHistoryEntries(conn *sqlite.Conn, entryOrEntries interface{}) {
+	MustPreload(conn, entryOrEntries,
+		hades.Assoc("Game"),
+		hades.Assoc("Upload"),
+		hades.Assoc("Build"),
+	)
+}
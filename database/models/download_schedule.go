@@ -0,0 +1,82 @@
+package models
+
+import (
+	"encoding/json"
+
+	"crawshaw.io/sqlite"
+	"github.com/pkg/errors"
+	"xorm.io/builder"
+)
+
+// DownloadSchedule controls when, and how fast, downloads are
+// performed. There's at most one row.
+type DownloadSchedule struct {
+	ID int64 `json:"id" hades:"primary_key"`
+
+	// JSON-encoded []*DownloadWindow
+	Windows JSON `json:"windows"`
+
+	// Max rate in kbps outside of windows, 0 means unlimited
+	DefaultMaxRate int64 `json:"defaultMaxRate"`
+	// Max rate in kbps for updates, 0 means no separate limit
+	UpdateMaxRate int64 `json:"updateMaxRate"`
+	// If true, updates are only performed during windows
+	UpdatesOnlyInWindows bool `json:"updatesOnlyInWindows"`
+}
+
+// DownloadWindow is a time-of-day range, in local time.
+type DownloadWindow struct {
+	// Minutes since midnight
+	StartMinute int64 `json:"startMinute"`
+	// Minutes since midnight, may be less than StartMinute
+	// for windows that span midnight
+	EndMinute int64 `json:"endMinute"`
+	// Max rate in kbps during the window, 0 means unlimited
+	MaxRate int64 `json:"maxRate"`
+}
+
+const downloadScheduleID = 1
+
+// GetDownloadSchedule returns the saved schedule, or an empty one,
+// which doesn't limit anything.
+func GetDownloadSchedule(conn *sqlite.Conn) *DownloadSchedule {
+	var s DownloadSchedule
+	if MustSelectOne(conn, &s, builder.Eq{"id": downloadScheduleID}) {
+		return &s
+	}
+	return &DownloadSchedule{
+		ID:      downloadScheduleID,
+		Windows: "[]",
+	}
+}
+
+func (s *DownloadSchedule) Save(conn *sqlite.Conn) {
+	s.ID = downloadScheduleID
+	MustSave(conn, s)
+}
+
+func (s *DownloadSchedule) GetWindows() ([]*DownloadWindow, error) {
+	var windows []*DownloadWindow
+	if s.Windows == "" {
+		return nil, nil
+	}
+
+	err := json.Unmarshal([]byte(s.Windows), &windows)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshalling download windows")
+	}
+	return windows, nil
+}
+
+func (s *DownloadSchedule) SetWindows(windows []*DownloadWindow) error {
+	if windows == nil {
+		windows = []*DownloadWindow{}
+	}
+
+	contents, err := json.Marshal(windows)
+	if err != nil {
+		return errors.Wrap(err, "marshalling download windows")
+	}
+	s.Windows = JSON(contents)
+	return nil
+}
@@ -13,6 +13,10 @@ type InstallLocation struct {
 
 	Path string `json:"path"`
 
+	// Installs to this location are checked against their
+	// build's signature once they're done
+	VerifyInstalls bool `json:"verifyInstalls"`
+
 	Caves []*Cave `json:"caves"`
 }
 
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"crawshaw.io/sqlite"
+	itchio "github.com/itchio/go-itchio"
+	"github.com/itchio/hades"
+	"github.com/pkg/errors"
+)
+
+// A LaunchSession is recorded every time a cave is launched, whether
+// the game ran fine, crashed, or failed to start at all.
+type LaunchSession struct {
+	// An UUID
+	ID string `json:"id" hades:"primary_key"`
+
+	// The cave that was launched. It may have been uninstalled since.
+	CaveID string `json:"caveId"`
+
+	GameID int64        `json:"gameId"`
+	Game   *itchio.Game `json:"game"`
+
+	UploadID int64          `json:"uploadId"`
+	Upload   *itchio.Upload `json:"upload"`
+
+	BuildID int64         `json:"buildId"`
+	Build   *itchio.Build `json:"build"`
+
+	// When the game actually started running, or when the launch
+	// started if it never got that far
+	StartedAt time.Time `json:"startedAt"`
+	// Nil while the game is running, or if butler exited before it did
+	EndedAt *time.Time `json:"endedAt"`
+
+	// Runtime of the host the game was launched on, like "linux-amd64"
+	Host string `json:"host"`
+	// Full path to the wrapper (wine, etc.), if any
+	Wrapper string `json:"wrapper"`
+	// "native", "html", "url" or "shell"
+	Strategy string `json:"strategy"`
+
+	// Only known for strategies that run a process
+	ExitCode *int64 `json:"exitCode"`
+	// JSON-encoded []string, the last lines written by the game
+	Stdout JSON `json:"stdout"`
+	Stderr JSON `json:"stderr"`
+
+	// Short error message, if the launch failed
+	ErrorMessage *string `json:"errorMessage"`
+}
+
+func (s *LaunchSession) Save(conn *sqlite.Conn) {
+	MustSave(conn, s)
+}
+
+func (s *LaunchSession) GetStdout() ([]string, error) {
+	return unmarshalLines(s.Stdout)
+}
+
+func (s *LaunchSession) SetStdout(lines []string) error {
+	return marshalLines(&s.Stdout, lines)
+}
+
+func (s *LaunchSession) GetStderr() ([]string, error) {
+	return unmarshalLines(s.Stderr)
+}
+
+func (s *LaunchSession) SetStderr(lines []string) error {
+	return marshalLines(&s.Stderr, lines)
+}
+
+func unmarshalLines(in JSON) ([]string, error) {
+	var lines []string
+	if in == "" {
+		return nil, nil
+	}
+
+	err := json.Unmarshal([]byte(in), &lines)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshalling output lines")
+	}
+	return lines, nil
+}
+
+func marshalLines(out *JSON, lines []string) error {
+	if lines == nil {
+		lines = []string{}
+	}
+
+	contents, err := json.Marshal(lines)
+	if err != nil {
+		return errors.Wrap(err, "marshalling output lines")
+	}
+	*out = JSON(contents)
+	return nil
+}
+
+func PreloadLaunchSessions(conn *sqlite.Conn, sessionOrSessions interface{}) {
+	MustPreload(conn, sessionOrSessions,
+		hades.Assoc("Game"),
+		hades.Assoc("Upload"),
+		hades.Assoc("Build"),
+	)
+}
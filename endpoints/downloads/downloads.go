@@ -14,4 +14,10 @@ func Register(router *butlerd.Router) {
 	messages.DownloadsClearFinished.Register(router, DownloadsClearFinished)
 	messages.DownloadsDiscard.Register(router, DownloadsDiscard)
 	messages.DownloadsRetry.Register(router, DownloadsRetry)
+	messages.DownloadsPause.Register(router, DownloadsPause)
+	messages.DownloadsResume.Register(router, DownloadsResume)
+	messages.DownloadsGetSchedule.Register(router, DownloadsGetSchedule)
+	messages.DownloadsSetSchedule.Register(router, DownloadsSetSchedule)
+	messages.DownloadsHistory.Register(router, DownloadsHistory)
+	messages.DownloadsExport.Register(router, DownloadsExport)
 }
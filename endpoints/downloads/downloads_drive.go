@@ -3,17 +3,14 @@ package downloads
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"strings"
 	"time"
 
-	"github.com/itchio/wharf/werrors"
-
 	"github.com/itchio/httpkit/neterr"
-	"github.com/itchio/httpkit/timeout"
 
-	"github.com/itchio/butler/butlerd/jsonrpc2"
+	"github.com/itchio/butler/butlerd/bandwidth"
+	"github.com/itchio/butler/butlerd/horror"
+	"github.com/itchio/butler/butlerd/netstatus"
 
 	"github.com/pkg/errors"
 
@@ -30,14 +27,21 @@ import (
 
 var downloadsDriveCancelID = "Downloads.Drive"
 
-const pingURL = "https://itch.io/static/ping.txt"
+type tempLockfileErr interface {
+	Temporary() bool
+}
 
-type Status struct {
-	Online bool
+// A downloadSlot is a download being performed by the drive
+type downloadSlot struct {
+	download *models.Download
+	cancel   context.CancelFunc
+	stopping bool
+	throttle *bandwidth.Throttle
 }
 
-type tempLockfileErr interface {
-	Temporary() bool
+type downloadSlotResult struct {
+	downloadID string
+	err        error
 }
 
 func DownloadsDrive(rc *butlerd.RequestContext, params butlerd.DownloadsDriveParams) (*butlerd.DownloadsDriveResult, error) {
@@ -45,7 +49,12 @@ func DownloadsDrive(rc *butlerd.RequestContext, params butlerd.DownloadsDrivePar
 
 	// TODO: implement downloads drive lock via the database.
 
-	consumer.Infof("Now driving downloads...")
+	maxConcurrent := int(params.MaxConcurrent)
+	if maxConcurrent <= 0 {
+		maxConcurrent = 1
+	}
+	consumer.Infof("Now driving downloads (%d at a time)...", maxConcurrent)
+	rp := newRetryPolicy(params.RetryPolicy)
 
 	parentCtx := rc.Ctx
 	ctx, cancelFunc := context.WithCancel(parentCtx)
@@ -53,8 +62,26 @@ func DownloadsDrive(rc *butlerd.RequestContext, params butlerd.DownloadsDrivePar
 	rc.CancelFuncs.Add(downloadsDriveCancelID, cancelFunc)
 	defer rc.CancelFuncs.Remove(downloadsDriveCancelID)
 
-	status := &Status{
-		Online: true,
+	defer bandwidth.Set(bandwidth.SourceSchedule, 0)
+
+	slots := make(map[string]*downloadSlot)
+	results := make(chan downloadSlotResult)
+	var driveErr error
+
+	onResult := func(result downloadSlotResult) {
+		if slot, ok := slots[result.downloadID]; ok {
+			slot.throttle.Close()
+		}
+		delete(slots, result.downloadID)
+		if result.err == nil {
+			return
+		}
+
+		if result.err == butlerd.CodeNetworkDisconnected {
+			netstatus.ReportOffline(result.err)
+		} else {
+			consumer.Warnf("%+v", errors.WithMessage(result.err, "while performing download:"))
+		}
 	}
 
 poll:
@@ -68,70 +95,207 @@ poll:
 			// let's keep going
 		}
 
-		err := cleanDiscarded(rc)
+	collect:
+		for {
+			select {
+			case result := <-results:
+				onResult(result)
+			default:
+				break collect
+			}
+		}
+
+		policy, err := loadSchedulePolicy(rc, time.Now())
 		if err != nil {
-			consumer.Warnf("%+v", errors.WithMessage(err, "while cleaning discarded:"))
+			consumer.Warnf("%+v", errors.WithMessage(err, "while loading download schedule:"))
+			policy = unlimitedSchedulePolicy
 		}
+		cond := performableDownloadsCond(policy)
+
+		stopDisplacedSlots(rc, slots, maxConcurrent, cond)
 
-		err = performOne(ctx, rc)
+		err = cleanDiscarded(rc, slots)
 		if err != nil {
-			if err == butlerd.CodeNetworkDisconnected {
-				err = waitForInternet(rc, status)
-				if err != nil {
-					consumer.Warnf("%+v", errors.WithMessage(err, "while waiting for internet:"))
+			consumer.Warnf("%+v", errors.WithMessage(err, "while cleaning discarded:"))
+		}
+
+		if netstatus.Get().Status != netstatus.Online {
+			err = waitForInternet(ctx, rc)
+			if err != nil {
+				if ctx.Err() != nil {
+					continue
 				}
-			} else {
-				consumer.Warnf("%+v", errors.WithMessage(err, "while performing download:"))
+
+				consumer.Errorf("%+v", errors.WithMessage(err, "while waiting for internet:"))
+				driveErr = errors.WithMessage(butlerd.CodeNetworkDisconnected, err.Error())
+				cancelFunc()
+				break poll
 			}
 		}
 
+		fillSlots(ctx, rc, slots, maxConcurrent, cond, rp, results)
+		applySchedulePolicy(policy, slots)
+
 		time.Sleep(1 * time.Second)
 	}
 
+	// cancelling ctx cancelled all slots, wait for them to wrap up
+	for len(slots) > 0 {
+		onResult(<-results)
+	}
+
+	if driveErr != nil {
+		return nil, driveErr
+	}
+
 	res := &butlerd.DownloadsDriveResult{}
 	return res, nil
 }
 
-func waitForInternet(rc *butlerd.RequestContext, status *Status) error {
+// performableDownloadsCond matches downloads the drive should perform
+func performableDownloadsCond(policy *schedulePolicy) builder.Cond {
+	cond := builder.And(
+		builder.IsNull{"finished_at"},
+		builder.Not{builder.Expr("discarded")},
+		builder.Not{builder.Expr("paused")},
+		builder.Or(
+			builder.IsNull{"next_attempt_at"},
+			builder.Lte{"next_attempt_at": time.Now().UTC().Format(time.RFC3339Nano)},
+		),
+	)
+	if !policy.updatesAllowed {
+		cond = cond.And(builder.Neq{"reason": string(butlerd.DownloadReasonUpdate)})
+	}
+	return cond
+}
+
+// fillSlots starts performing the highest-priority downloads matching
+// cond, as long as there are free slots.
+func fillSlots(ctx context.Context, rc *butlerd.RequestContext, slots map[string]*downloadSlot, maxConcurrent int, cond builder.Cond, rp retryPolicy, results chan<- downloadSlotResult) {
+	if len(slots) >= maxConcurrent {
+		return
+	}
+
+	var pendingDownloads []*models.Download
+	rc.WithConn(func(conn *sqlite.Conn) {
+		models.MustSelect(conn, &pendingDownloads,
+			cond,
+			hades.Search{}.OrderBy("position ASC").Limit(int64(maxConcurrent)),
+		)
+		models.PreloadDownloads(conn, pendingDownloads)
+	})
+
+	for _, download := range pendingDownloads {
+		if len(slots) >= maxConcurrent {
+			break
+		}
+		if _, ok := slots[download.ID]; ok {
+			continue
+		}
+
+		slotCtx, cancelFunc := context.WithCancel(ctx)
+		slot := &downloadSlot{
+			download: download,
+			cancel:   cancelFunc,
+			throttle: bandwidth.NewThrottle(),
+		}
+		slot.throttle.OnRead(countDownloadedBytes)
+		slots[download.ID] = slot
+		rc.Consumer.Infof("Performing for %s (%d/%d slots used)", operate.GameToString(download.Game), len(slots), maxConcurrent)
+
+		slotRc := rc.Fork()
+		slotRc.Throttle = slot.throttle
+
+		go func(download *models.Download, slotRc *butlerd.RequestContext) {
+			defer cancelFunc()
+
+			var err error
+			func() {
+				defer horror.RecoverInto(&err)
+				err = performOne(slotCtx, slotRc, download, rp)
+			}()
+			results <- downloadSlotResult{
+				downloadID: download.ID,
+				err:        err,
+			}
+		}(download, slotRc)
+	}
+}
+
+// stopDisplacedSlots cancels downloads that no longer match cond (they were
+// discarded, paused, or are outside download windows), or that are no longer
+// among the highest-priority ones, because others were prioritized or
+// queued in front of them.
+func stopDisplacedSlots(rc *butlerd.RequestContext, slots map[string]*downloadSlot, maxConcurrent int, cond builder.Cond) {
+	if len(slots) == 0 {
+		return
+	}
+
+	wanted := make(map[string]bool)
+	rc.WithConn(func(conn *sqlite.Conn) {
+		models.MustExecWithSearch(conn,
+			builder.Select("id").From("downloads").Where(cond),
+			hades.Search{}.OrderBy("position ASC").Limit(int64(maxConcurrent)),
+			func(stmt *sqlite.Stmt) error {
+				wanted[stmt.ColumnText(0)] = true
+				return nil
+			},
+		)
+	})
+
+	for id, slot := range slots {
+		if slot.stopping || wanted[id] {
+			continue
+		}
+
+		rc.Consumer.Infof("%s discarded, paused, deprioritized or out of schedule, bailing out!", id)
+		slot.stopping = true
+		slot.cancel()
+	}
+}
+
+// waitForInternet probes the network until it's back, see package netstatus.
+// It returns an error if probing was given up on, or ctx is done.
+func waitForInternet(ctx context.Context, rc *butlerd.RequestContext) error {
 	consumer := rc.Consumer
 
-	// notify always, but only log once
 	messages.DownloadsDriveNetworkStatus.Notify(rc, butlerd.DownloadsDriveNetworkStatusNotification{
 		Status: butlerd.NetworkStatusOffline,
 	})
-	if status.Online {
-		status.Online = false
-		consumer.Opf("Looks like we're offline! Waiting for an internet connection...")
-	}
-
-	client := timeout.NewDefaultClient()
+	consumer.Opf("Looks like we're offline! Waiting for an internet connection...")
 
-	// wait up to 120 rounds (2 minutes if tries take 0s,
-	// which they don't), then give up waiting
-	for i := 0; i < 120; i++ {
-		res, err := client.Get(pingURL)
-		if err != nil {
-			if neterr.IsNetworkError(err) {
-				// keep going...
-			} else {
-				consumer.Warnf("Got non-network error while pinging: %+v", err)
-			}
-		} else {
-			payload, _ := ioutil.ReadAll(res.Body)
-			consumer.Statf("Looks like we're back online! (%s)", strings.TrimSpace(string(payload)))
+	err := netstatus.WaitOnline(ctx)
+	if err != nil {
+		if errors.Cause(err) == netstatus.ErrGaveUp {
 			messages.DownloadsDriveNetworkStatus.Notify(rc, butlerd.DownloadsDriveNetworkStatusNotification{
-				Status: butlerd.NetworkStatusOnline,
+				Status: butlerd.NetworkStatusUnreachable,
 			})
-			status.Online = true
-			return nil
 		}
-
-		time.Sleep(1 * time.Second)
+		return err
 	}
+
+	consumer.Statf("Looks like we're back online! (probed %s)", netstatus.Get().ProbeURL)
+
+	// downloads that failed because we were offline can be retried right
+	// away, those backing off from other errors still have to wait.
+	rc.WithConn(func(conn *sqlite.Conn) {
+		models.MustUpdate(conn, &models.Download{},
+			hades.Where(builder.And(
+				builder.IsNull{"finished_at"},
+				builder.Eq{"error_code": int64(butlerd.CodeNetworkDisconnected)},
+			)),
+			builder.Eq{"next_attempt_at": nil},
+		)
+	})
+	messages.DownloadsDriveNetworkStatus.Notify(rc, butlerd.DownloadsDriveNetworkStatusNotification{
+		Status: butlerd.NetworkStatusOnline,
+	})
 	return nil
 }
 
-func cleanDiscarded(rc *butlerd.RequestContext) error {
+// cleanDiscarded wipes and deletes discarded downloads, except those still
+// being performed: they're cleaned up once their slot is done.
+func cleanDiscarded(rc *butlerd.RequestContext, slots map[string]*downloadSlot) error {
 	consumer := rc.Consumer
 
 	var discardedDownloads []*models.Download
@@ -140,6 +304,10 @@ func cleanDiscarded(rc *butlerd.RequestContext) error {
 		models.PreloadDownloads(conn, discardedDownloads)
 	})
 	for _, download := range discardedDownloads {
+		if _, ok := slots[download.ID]; ok {
+			continue
+		}
+
 		consumer.Opf("Cleaning up download for %s", operate.GameToString(download.Game))
 
 		if download.StagingFolder == "" {
@@ -168,98 +336,44 @@ func cleanDiscarded(rc *butlerd.RequestContext) error {
 			models.MustDelete(conn, download, builder.Eq{"id": download.ID})
 		})
 
+		rc.SetEventScope(butlerd.EventScope{
+			CaveID:     download.CaveID,
+			DownloadID: download.ID,
+		})
 		messages.DownloadsDriveDiscarded.Notify(rc, butlerd.DownloadsDriveDiscardedNotification{
 			Download: formatDownload(download),
 		})
+		rc.SetEventScope(butlerd.EventScope{})
 	}
 	return nil
 }
 
-func performOne(parentCtx context.Context, rc *butlerd.RequestContext) error {
-	consumer := rc.Consumer
-
-	var pendingDownloads []*models.Download
-	var download *models.Download
+// isDiscarded returns true if the download was discarded since it
+// was loaded.
+func isDiscarded(rc *butlerd.RequestContext, downloadID string) bool {
+	var discarded bool
 	rc.WithConn(func(conn *sqlite.Conn) {
-		models.MustSelect(conn, &pendingDownloads,
-			builder.And(
-				builder.IsNull{"finished_at"},
-				builder.Not{builder.Expr("discarded")},
-			),
-			hades.Search{}.OrderBy("position ASC"),
+		models.MustExec(conn,
+			builder.Select("discarded").From("downloads").Where(builder.Eq{"id": downloadID}),
+			func(stmt *sqlite.Stmt) error {
+				discarded = stmt.ColumnInt(0) == 1
+				return nil
+			},
 		)
-		if len(pendingDownloads) == 0 {
-			return
-		}
-
-		download = pendingDownloads[0]
-		download.Preload(conn)
 	})
-	if download == nil {
-		return nil
-	}
-	consumer.Infof("%d pending downloads, performing for %s", len(pendingDownloads), operate.GameToString(download.Game))
+	return discarded
+}
 
-	ctx, cancelFunc := context.WithCancel(parentCtx)
-	defer cancelFunc()
-
-	wasDiscarded := func() bool {
-		// have we been discarded?
-		{
-			var discarded bool
-			rc.WithConn(func(conn *sqlite.Conn) {
-				models.MustExec(conn,
-					builder.Select("discarded").From("downloads").Where(builder.Eq{"id": download.ID}),
-					func(stmt *sqlite.Stmt) error {
-						discarded = stmt.ColumnInt(0) == 1
-						return nil
-					},
-				)
-			})
-			if discarded {
-				consumer.Infof("Download was cancelled from under us, bailing out!")
-				return true
-			}
-		}
+// performOne performs a single download. rc must not be shared with
+// other slots, see butlerd.RequestContext.Fork.
+func performOne(ctx context.Context, rc *butlerd.RequestContext, download *models.Download, rp retryPolicy) error {
+	consumer := rc.Consumer
 
-		// has something else been prioritized?
-		{
-			var priorityDownloadID string
-			rc.WithConn(func(conn *sqlite.Conn) {
-				models.MustExecWithSearch(conn,
-					builder.Select("id").From("downloads").Where(
-						builder.And(
-							builder.IsNull{"finished_at"},
-							builder.Not{builder.Expr("discarded")},
-						),
-					),
-					hades.Search{}.Limit(1),
-					func(stmt *sqlite.Stmt) error {
-						priorityDownloadID = stmt.ColumnText(0)
-						return nil
-					},
-				)
-			})
-			if priorityDownloadID != download.ID {
-				consumer.Infof("%s deprioritized (for %s), bailing out!", download.ID, priorityDownloadID)
-				return true
-			}
-		}
-		return false
-	}
-	goGadgetoDiscardWatcher := func() {
-		for {
-			select {
-			case <-time.After(5 * time.Second):
-				if wasDiscarded() {
-					cancelFunc()
-				}
-			case <-ctx.Done():
-				return
-			}
-		}
-	}
-	go goGadgetoDiscardWatcher()
+	rc.SetEventScope(butlerd.EventScope{
+		CaveID:     download.CaveID,
+		DownloadID: download.ID,
+	})
+	defer rc.SetEventScope(butlerd.EventScope{})
 
 	var stage = "prepare"
 	var progress, eta, bps float64
@@ -268,6 +382,12 @@ func performOne(parentCtx context.Context, rc *butlerd.RequestContext) error {
 
 	lastProgress := time.Now()
 
+	// bytes transferred are estimated from progress notifications,
+	// since installers don't report them.
+	startedAt := time.Now().UTC()
+	var bytesTransferred float64
+	lastSampleAt := startedAt
+
 	sendProgress := func() error {
 		if time.Since(lastProgress).Seconds() < 0.5 {
 			return nil
@@ -294,6 +414,9 @@ func performOne(parentCtx context.Context, rc *butlerd.RequestContext) error {
 	defer rc.StopInterceptingNotification(messages.Progress.Method())
 	rc.InterceptNotification(messages.Progress.Method(), func(method string, paramsIn interface{}) error {
 		params := paramsIn.(butlerd.ProgressNotification)
+		now := time.Now().UTC()
+		bytesTransferred += bps * now.Sub(lastSampleAt).Seconds()
+		lastSampleAt = now
 		progress = params.Progress
 		eta = params.ETA
 		bps = params.BPS
@@ -312,6 +435,7 @@ func performOne(parentCtx context.Context, rc *butlerd.RequestContext) error {
 		return nil
 	})
 
+	strategy := "none"
 	err := func() (err error) {
 		defer func() {
 			if r := recover(); r != nil {
@@ -328,23 +452,41 @@ func performOne(parentCtx context.Context, rc *butlerd.RequestContext) error {
 			Download: formatDownload(download),
 		})
 
-		_, err = operate.InstallPerform(ctx, rc, butlerd.InstallPerformParams{
+		_, strategy, err = operate.InstallPerformWithStrategy(ctx, rc, butlerd.InstallPerformParams{
 			ID:            download.ID,
 			StagingFolder: download.StagingFolder,
 		})
 		return
 	}()
+
+	recordHistory := func(errorCode *int64, errorMessage *string) {
+		finishedAt := time.Now().UTC()
+		bytesTransferred += bps * finishedAt.Sub(lastSampleAt).Seconds()
+		recordDownloadHistory(rc, download, downloadHistoryStats{
+			Strategy:         strategy,
+			StartedAt:        startedAt,
+			FinishedAt:       finishedAt,
+			BytesTransferred: int64(bytesTransferred),
+			SpeedHistory:     speedHistory,
+			ErrorCode:        errorCode,
+			ErrorMessage:     errorMessage,
+		})
+	}
 	if err != nil {
-		if wasDiscarded() {
+		if ctx.Err() != nil {
+			// the slot was stopped, or the whole drive was cancelled,
+			// the download will be picked up again later if needed.
+			consumer.Infof("Download stopped")
+			return nil
+		}
+
+		if isDiscarded(rc, download.ID) {
 			// download errored, but it was already discarded, ignoring.
 			return nil
 		}
 
 		if be, ok := butlerd.AsButlerdError(err); ok {
 			switch butlerd.Code(be.RpcErrorCode()) {
-			case butlerd.CodeNetworkDisconnected:
-				// propagate so we can wait for the connection to be re-established
-				return butlerd.CodeNetworkDisconnected
 			case butlerd.CodeOperationCancelled:
 				// the whole drive was probably cancelled?
 				return nil
@@ -355,27 +497,45 @@ func performOne(parentCtx context.Context, rc *butlerd.RequestContext) error {
 				})
 				return nil
 			}
+		}
 
-			code := be.RpcErrorCode()
-			download.ErrorCode = &code
-			msg := be.RpcErrorMessage()
-			download.ErrorMessage = &msg
-		} else {
-			var code int64
-			var msg string
-			if neterr.IsNetworkError(err) {
-				return butlerd.CodeNetworkDisconnected
-			} else if errors.Cause(err) == werrors.ErrCancelled {
-				// just cancelled, nothing to see here
+		code, msg := downloadErrorCode(err)
+
+		if isTransientError(err) {
+			download.Attempts++
+			if download.Attempts < rp.maxAttempts {
+				delay := rp.delay(download.Attempts)
+				nextAttemptAt := time.Now().UTC().Add(delay)
+				download.NextAttemptAt = &nextAttemptAt
+				consumer.Warnf("Download failed (attempt %d/%d), retrying in %s: %v", download.Attempts, rp.maxAttempts, delay, err)
+
+				// only update retry columns, so we don't clobber changes
+				// made while the download was being performed.
+				rc.WithConn(func(conn *sqlite.Conn) {
+					models.MustUpdate(conn, &models.Download{},
+						hades.Where(builder.Eq{"id": download.ID}),
+						builder.Eq{
+							"attempts":        download.Attempts,
+							"next_attempt_at": nextAttemptAt.Format(time.RFC3339Nano),
+							"error_code":      code,
+						},
+					)
+				})
+				recordHistory(&code, &msg)
+
+				if neterr.IsNetworkError(err) || butlerd.Code(code) == butlerd.CodeNetworkDisconnected {
+					// propagate so we can wait for the connection to be re-established
+					return butlerd.CodeNetworkDisconnected
+				}
 				return nil
-			} else {
-				code = int64(jsonrpc2.CodeInternalError)
-				msg = err.Error()
 			}
-			download.ErrorCode = &code
-			download.ErrorMessage = &msg
+			consumer.Warnf("Giving up after %d attempts", download.Attempts)
+			download.NextAttemptAt = nil
 		}
 
+		download.ErrorCode = &code
+		download.ErrorMessage = &msg
+
 		var errString = fmt.Sprintf("%+v", err)
 		consumer.Warnf("Download errored: %s", errString)
 		download.Error = &errString
@@ -383,6 +543,7 @@ func performOne(parentCtx context.Context, rc *butlerd.RequestContext) error {
 		finishedAt := time.Now().UTC()
 		download.FinishedAt = &finishedAt
 		rc.WithConn(download.Save)
+		recordHistory(download.ErrorCode, download.ErrorMessage)
 
 		messages.DownloadsDriveErrored.Notify(rc, butlerd.DownloadsDriveErroredNotification{
 			Download: formatDownload(download),
@@ -394,7 +555,10 @@ func performOne(parentCtx context.Context, rc *butlerd.RequestContext) error {
 	consumer.Infof("Download finished!")
 	finishedAt := time.Now().UTC()
 	download.FinishedAt = &finishedAt
+	// clear the code of the last failed attempt, if any
+	download.ErrorCode = nil
 	rc.WithConn(download.Save)
+	recordHistory(nil, nil)
 
 	messages.DownloadsDriveFinished.Notify(rc, butlerd.DownloadsDriveFinishedNotification{
 		Download: formatDownload(download),
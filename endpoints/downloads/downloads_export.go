@@ -0,0 +1,59 @@
+package downloads
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"crawshaw.io/sqlite"
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/database/models"
+	"github.com/itchio/hades"
+	"github.com/pkg/errors"
+	"xorm.io/builder"
+)
+
+func DownloadsExport(rc *butlerd.RequestContext, params butlerd.DownloadsExportParams) (*butlerd.DownloadsExportResult, error) {
+	consumer := rc.Consumer
+
+	var downloads []*models.Download
+	rc.WithConn(func(conn *sqlite.Conn) {
+		models.MustSelect(conn, &downloads,
+			builder.And(
+				builder.IsNull{"finished_at"},
+				builder.Not{builder.Expr("discarded")},
+			),
+			hades.Search{}.OrderBy("position ASC"),
+		)
+	})
+
+	manifest := &butlerd.DownloadManifest{
+		Version: 1,
+		Entries: []*butlerd.DownloadManifestEntry{},
+	}
+	for _, d := range downloads {
+		manifest.Entries = append(manifest.Entries, &butlerd.DownloadManifestEntry{
+			GameID:            d.GameID,
+			UploadID:          d.UploadID,
+			BuildID:           d.BuildID,
+			Reason:            butlerd.DownloadReason(d.Reason),
+			CaveID:            d.CaveID,
+			InstallLocationID: d.InstallLocationID,
+		})
+	}
+
+	payload, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	err = ioutil.WriteFile(params.Path, payload, 0o644)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	consumer.Infof("Exported %d downloads to (%s)", len(manifest.Entries), params.Path)
+
+	res := &butlerd.DownloadsExportResult{
+		Manifest: manifest,
+	}
+	return res, nil
+}
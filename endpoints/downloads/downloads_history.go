@@ -0,0 +1,113 @@
+package downloads
+
+import (
+	"time"
+
+	"crawshaw.io/sqlite"
+	"github.com/google/uuid"
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/database/models"
+	"github.com/itchio/butler/endpoints/fetch/pager"
+	"github.com/itchio/hades"
+	"xorm.io/builder"
+)
+
+func DownloadsHistory(rc *butlerd.RequestContext, params butlerd.DownloadsHistoryParams) (*butlerd.DownloadsHistoryResult, error) {
+	res := &butlerd.DownloadsHistoryResult{
+		Items: []*butlerd.DownloadHistoryEntry{},
+	}
+
+	rc.WithConn(func(conn *sqlite.Conn) {
+		cond := builder.NewCond()
+		if params.CaveID != "" {
+			cond = cond.And(builder.Eq{"cave_id": params.CaveID})
+		}
+		if params.GameID != 0 {
+			cond = cond.And(builder.Eq{"game_id": params.GameID})
+		}
+		search := hades.Search{}.OrderBy("finished_at " + pager.Ordering("DESC", params.Reverse))
+
+		var entries []*models.DownloadHistoryEntry
+		pg := pager.New(params)
+		res.NextCursor = pg.Fetch(conn, &entries, cond, search)
+		models.PreloadDownloadHistoryEntries(conn, entries)
+		for _, e := range entries {
+			res.Items = append(res.Items, formatDownloadHistoryEntry(rc, e))
+		}
+	})
+	return res, nil
+}
+
+func formatDownloadHistoryEntry(rc *butlerd.RequestContext, e *models.DownloadHistoryEntry) *butlerd.DownloadHistoryEntry {
+	speedHistory, err := e.GetSpeedHistory()
+	if err != nil {
+		rc.Consumer.Warnf("Invalid speed history for download history entry %s: %v", e.ID, err)
+	}
+	if speedHistory == nil {
+		speedHistory = []float64{}
+	}
+
+	return &butlerd.DownloadHistoryEntry{
+		ID:               e.ID,
+		DownloadID:       e.DownloadID,
+		CaveID:           e.CaveID,
+		Game:             e.Game,
+		Upload:           e.Upload,
+		Build:            e.Build,
+		Reason:           butlerd.DownloadReason(e.Reason),
+		Strategy:         e.Strategy,
+		StartedAt:        e.StartedAt,
+		FinishedAt:       e.FinishedAt,
+		WallTime:         e.WallTime,
+		BytesTransferred: e.BytesTransferred,
+		AverageBPS:       e.AverageBPS,
+		SpeedHistory:     speedHistory,
+		ErrorCode:        e.ErrorCode,
+		ErrorMessage:     e.ErrorMessage,
+	}
+}
+
+type downloadHistoryStats struct {
+	Strategy         string
+	StartedAt        time.Time
+	FinishedAt       time.Time
+	BytesTransferred int64
+	SpeedHistory     []float64
+	ErrorCode        *int64
+	ErrorMessage     *string
+}
+
+// recordDownloadHistory adds an entry to the download history once the
+// drive is done performing a download.
+func recordDownloadHistory(rc *butlerd.RequestContext, download *models.Download, stats downloadHistoryStats) {
+	wallTime := stats.FinishedAt.Sub(stats.StartedAt).Seconds()
+	var averageBPS float64
+	if wallTime > 0 {
+		averageBPS = float64(stats.BytesTransferred) / wallTime
+	}
+
+	entry := &models.DownloadHistoryEntry{
+		ID:               uuid.New().String(),
+		DownloadID:       download.ID,
+		CaveID:           download.CaveID,
+		GameID:           download.GameID,
+		UploadID:         download.UploadID,
+		BuildID:          download.BuildID,
+		Reason:           download.Reason,
+		Strategy:         stats.Strategy,
+		StartedAt:        stats.StartedAt,
+		FinishedAt:       stats.FinishedAt,
+		WallTime:         wallTime,
+		BytesTransferred: stats.BytesTransferred,
+		AverageBPS:       averageBPS,
+		ErrorCode:        stats.ErrorCode,
+		ErrorMessage:     stats.ErrorMessage,
+	}
+	err := entry.SetSpeedHistory(stats.SpeedHistory)
+	if err != nil {
+		rc.Consumer.Warnf("Could not record download history: %v", err)
+		return
+	}
+
+	rc.WithConn(entry.Save)
+}
@@ -39,5 +39,8 @@ func formatDownload(download *models.Download) *butlerd.Download {
 		FinishedAt:    download.FinishedAt,
 		StagingFolder: download.StagingFolder,
 		Reason:        butlerd.DownloadReason(download.Reason),
+		Paused:        download.Paused,
+		Attempts:      download.Attempts,
+		NextAttemptAt: download.NextAttemptAt,
 	}
 }
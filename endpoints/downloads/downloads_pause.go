@@ -0,0 +1,60 @@
+package downloads
+
+import (
+	"crawshaw.io/sqlite"
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/cmd/operate"
+	"github.com/itchio/butler/database/models"
+	"github.com/itchio/hades"
+	"github.com/pkg/errors"
+	"xorm.io/builder"
+)
+
+func DownloadsPause(rc *butlerd.RequestContext, params butlerd.DownloadsPauseParams) (*butlerd.DownloadsPauseResult, error) {
+	consumer := rc.Consumer
+
+	err := setDownloadPaused(rc, params.DownloadID, true)
+	if err != nil {
+		return nil, err
+	}
+	consumer.Statf("Paused download %s", params.DownloadID)
+
+	res := &butlerd.DownloadsPauseResult{}
+	return res, nil
+}
+
+func DownloadsResume(rc *butlerd.RequestContext, params butlerd.DownloadsResumeParams) (*butlerd.DownloadsResumeResult, error) {
+	consumer := rc.Consumer
+
+	err := setDownloadPaused(rc, params.DownloadID, false)
+	if err != nil {
+		return nil, err
+	}
+	consumer.Statf("Resumed download %s", params.DownloadID)
+
+	res := &butlerd.DownloadsResumeResult{}
+	return res, nil
+}
+
+// setDownloadPaused only updates the paused column, so it doesn't
+// clobber changes made by a drive performing the download.
+func setDownloadPaused(rc *butlerd.RequestContext, downloadID string, paused bool) error {
+	var err error
+	rc.WithConn(func(conn *sqlite.Conn) {
+		download := ValidateDownload(conn, downloadID)
+		if download.Discarded {
+			err = errors.Errorf("Download for %s was discarded", operate.GameToString(download.Game))
+			return
+		}
+		if download.FinishedAt != nil {
+			err = errors.Errorf("Download for %s has already finished", operate.GameToString(download.Game))
+			return
+		}
+
+		models.MustUpdate(conn, &models.Download{},
+			hades.Where(builder.Eq{"id": download.ID}),
+			builder.Eq{"paused": paused},
+		)
+	})
+	return err
+}
@@ -83,6 +83,14 @@ func DownloadsQueue(rc *butlerd.RequestContext, params butlerd.DownloadsQueuePar
 		hades.Assoc("Build"),
 	)
 
+	// queued downloads hold on to their space until they're performed,
+	// so that downloads queued after them can't count it as free.
+	err = operate.ReserveDiskSpace(conn, consumer, d.InstallLocationID, d.CaveID, d.StagingFolder, operate.PlannedDiskSpace(d.StagingFolder))
+	if err != nil {
+		models.MustDelete(conn, &models.Download{}, builder.Eq{"id": d.ID})
+		return nil, errors.WithStack(err)
+	}
+
 	if item.CaveID != "" {
 		// remove other downloads for this cave
 		models.MustDelete(conn, &models.Download{},
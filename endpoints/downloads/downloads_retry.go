@@ -20,6 +20,8 @@ func DownloadsRetry(rc *butlerd.RequestContext, params butlerd.DownloadsRetryPar
 			download.ErrorCode = nil
 			download.ErrorMessage = nil
 			download.FinishedAt = nil
+			download.Attempts = 0
+			download.NextAttemptAt = nil
 			download.Save(conn)
 
 			consumer.Statf("Queued a retry for download for %s", operate.GameToString(download.Game))
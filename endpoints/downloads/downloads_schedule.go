@@ -0,0 +1,167 @@
+package downloads
+
+import (
+	"fmt"
+	"time"
+
+	"crawshaw.io/sqlite"
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/bandwidth"
+	"github.com/itchio/butler/database/models"
+	"github.com/pkg/errors"
+)
+
+func DownloadsGetSchedule(rc *butlerd.RequestContext, params butlerd.DownloadsGetScheduleParams) (*butlerd.DownloadsGetScheduleResult, error) {
+	var schedule *models.DownloadSchedule
+	rc.WithConn(func(conn *sqlite.Conn) {
+		schedule = models.GetDownloadSchedule(conn)
+	})
+
+	windows, err := schedule.GetWindows()
+	if err != nil {
+		return nil, err
+	}
+
+	formatted := &butlerd.DownloadSchedule{
+		Windows:              []*butlerd.DownloadWindow{},
+		DefaultMaxRate:       schedule.DefaultMaxRate,
+		UpdateMaxRate:        schedule.UpdateMaxRate,
+		UpdatesOnlyInWindows: schedule.UpdatesOnlyInWindows,
+	}
+	for _, w := range windows {
+		formatted.Windows = append(formatted.Windows, &butlerd.DownloadWindow{
+			Start:   formatTimeOfDay(w.StartMinute),
+			End:     formatTimeOfDay(w.EndMinute),
+			MaxRate: w.MaxRate,
+		})
+	}
+
+	res := &butlerd.DownloadsGetScheduleResult{
+		Schedule: formatted,
+	}
+	return res, nil
+}
+
+func DownloadsSetSchedule(rc *butlerd.RequestContext, params butlerd.DownloadsSetScheduleParams) (*butlerd.DownloadsSetScheduleResult, error) {
+	var windows []*models.DownloadWindow
+	for _, w := range params.Schedule.Windows {
+		start, err := parseTimeOfDay(w.Start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseTimeOfDay(w.End)
+		if err != nil {
+			return nil, err
+		}
+
+		windows = append(windows, &models.DownloadWindow{
+			StartMinute: start,
+			EndMinute:   end,
+			MaxRate:     w.MaxRate,
+		})
+	}
+
+	schedule := &models.DownloadSchedule{
+		DefaultMaxRate:       params.Schedule.DefaultMaxRate,
+		UpdateMaxRate:        params.Schedule.UpdateMaxRate,
+		UpdatesOnlyInWindows: params.Schedule.UpdatesOnlyInWindows,
+	}
+	err := schedule.SetWindows(windows)
+	if err != nil {
+		return nil, err
+	}
+
+	rc.WithConn(schedule.Save)
+	rc.Consumer.Statf("Download schedule set (%d windows)", len(windows))
+
+	res := &butlerd.DownloadsSetScheduleResult{}
+	return res, nil
+}
+
+// parseTimeOfDay turns "HH:MM" into minutes since midnight
+func parseTimeOfDay(s string) (int64, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, errors.Wrapf(err, "parsing time of day (%s)", s)
+	}
+	return int64(t.Hour()*60 + t.Minute()), nil
+}
+
+func formatTimeOfDay(minute int64) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+// A schedulePolicy is what the download schedule says about a given time
+type schedulePolicy struct {
+	// max rate in kbps, 0 means unlimited
+	rate int64
+	// max rate in kbps for each update being downloaded
+	updateRate int64
+	// false if updates should wait for a window
+	updatesAllowed bool
+}
+
+var unlimitedSchedulePolicy = &schedulePolicy{
+	updatesAllowed: true,
+}
+
+func loadSchedulePolicy(rc *butlerd.RequestContext, now time.Time) (*schedulePolicy, error) {
+	var schedule *models.DownloadSchedule
+	rc.WithConn(func(conn *sqlite.Conn) {
+		schedule = models.GetDownloadSchedule(conn)
+	})
+
+	windows, err := schedule.GetWindows()
+	if err != nil {
+		return nil, err
+	}
+
+	minute := int64(now.Hour()*60 + now.Minute())
+	var window *models.DownloadWindow
+	for _, w := range windows {
+		if windowContains(w, minute) {
+			window = w
+			break
+		}
+	}
+
+	p := &schedulePolicy{
+		rate:           schedule.DefaultMaxRate,
+		updatesAllowed: true,
+	}
+	if window != nil {
+		p.rate = window.MaxRate
+	} else if schedule.UpdatesOnlyInWindows {
+		p.updatesAllowed = false
+	}
+	p.updateRate = bandwidth.Min(p.rate, schedule.UpdateMaxRate)
+	return p, nil
+}
+
+func windowContains(w *models.DownloadWindow, minute int64) bool {
+	switch {
+	case w.StartMinute == w.EndMinute:
+		// all day long
+		return true
+	case w.StartMinute < w.EndMinute:
+		return minute >= w.StartMinute && minute < w.EndMinute
+	default:
+		// spans midnight
+		return minute >= w.StartMinute || minute < w.EndMinute
+	}
+}
+
+// applySchedulePolicy limits bandwidth according to the schedule: the
+// current rate applies to all downloads, and updates are further limited
+// to the update rate, without slowing down anything else.
+func applySchedulePolicy(policy *schedulePolicy, slots map[string]*downloadSlot) {
+	bandwidth.Set(bandwidth.SourceSchedule, policy.rate)
+
+	for _, slot := range slots {
+		if slot.download.Reason == string(butlerd.DownloadReasonUpdate) {
+			slot.throttle.Set(policy.updateRate)
+		} else {
+			slot.throttle.Set(0)
+		}
+	}
+}
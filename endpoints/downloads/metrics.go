@@ -0,0 +1,14 @@
+package downloads
+
+import (
+	"github.com/itchio/butler/butlerd/metrics"
+)
+
+var downloadedBytesTotal = metrics.Default.NewCounter("butlerd_downloads_drive_bytes_total",
+	"Bytes downloaded while driving downloads")
+
+// countDownloadedBytes is called for every read from a download slot's
+// connections, see bandwidth.Throttle.OnRead
+func countDownloadedBytes(n int64) {
+	downloadedBytesTotal.Add(float64(n))
+}
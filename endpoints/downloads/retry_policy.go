@@ -0,0 +1,104 @@
+package downloads
+
+import (
+	"time"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/jsonrpc2"
+	itchio "github.com/itchio/go-itchio"
+	"github.com/itchio/httpkit/htfs"
+	"github.com/itchio/httpkit/neterr"
+	"github.com/itchio/wharf/werrors"
+	"github.com/pkg/errors"
+)
+
+// retryPolicy decides whether, and when, failed downloads are attempted again
+type retryPolicy struct {
+	maxAttempts  int64
+	initialDelay time.Duration
+	maxDelay     time.Duration
+}
+
+var defaultRetryPolicy = retryPolicy{
+	maxAttempts:  5,
+	initialDelay: 10 * time.Second,
+	maxDelay:     5 * time.Minute,
+}
+
+// newRetryPolicy fills in the blanks in params with defaultRetryPolicy
+func newRetryPolicy(params *butlerd.DownloadRetryPolicy) retryPolicy {
+	rp := defaultRetryPolicy
+	if params == nil {
+		return rp
+	}
+
+	if params.MaxAttempts > 0 {
+		rp.maxAttempts = params.MaxAttempts
+	}
+	if params.InitialDelay > 0 {
+		rp.initialDelay = time.Duration(params.InitialDelay * float64(time.Second))
+	}
+	if params.MaxDelay > 0 {
+		rp.maxDelay = time.Duration(params.MaxDelay * float64(time.Second))
+	}
+	return rp
+}
+
+// delay returns how long to wait before the next attempt, given
+// how many attempts already failed.
+func (rp retryPolicy) delay(attempts int64) time.Duration {
+	delay := rp.initialDelay
+	for i := int64(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= rp.maxDelay {
+			return rp.maxDelay
+		}
+	}
+	if delay > rp.maxDelay {
+		return rp.maxDelay
+	}
+	return delay
+}
+
+// isTransientError returns true for errors that may go away on their own,
+// in which case the download is retried. Errors that are here to stay,
+// like butlerd.CodeNoCompatibleUploads, make the download fail right away.
+func isTransientError(err error) bool {
+	if neterr.IsNetworkError(err) {
+		return true
+	}
+
+	cause := errors.Cause(err)
+	if cause == werrors.ErrCancelled {
+		// the drive wasn't cancelled (we checked), so it's a timeout
+		return true
+	}
+
+	if be, ok := butlerd.AsButlerdError(err); ok {
+		return butlerd.Code(be.RpcErrorCode()) == butlerd.CodeNetworkDisconnected
+	}
+
+	if se, ok := cause.(*htfs.ServerError); ok {
+		return se.StatusCode/100 == 5
+	}
+
+	if ae, ok := itchio.AsAPIError(err); ok {
+		return ae.StatusCode/100 == 5
+	}
+
+	return false
+}
+
+// downloadErrorCode returns the butlerd error code and short message
+// to record for a download that failed with err.
+func downloadErrorCode(err error) (int64, string) {
+	if be, ok := butlerd.AsButlerdError(err); ok {
+		return be.RpcErrorCode(), be.RpcErrorMessage()
+	}
+
+	if neterr.IsNetworkError(err) {
+		return int64(butlerd.CodeNetworkDisconnected), err.Error()
+	}
+
+	return int64(jsonrpc2.CodeInternalError), err.Error()
+}
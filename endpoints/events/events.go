@@ -0,0 +1,24 @@
+package events
+
+import (
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/messages"
+)
+
+func Register(router *butlerd.Router) {
+	messages.EventsSubscribe.Register(router, func(rc *butlerd.RequestContext, params butlerd.EventsSubscribeParams) (*butlerd.EventsSubscribeResult, error) {
+		subscriptionID := router.SubscribeEvents(rc.Conn, butlerd.EventFilter{
+			Methods:     params.Methods,
+			CaveIDs:     params.CaveIDs,
+			DownloadIDs: params.DownloadIDs,
+		})
+		return &butlerd.EventsSubscribeResult{
+			SubscriptionID: subscriptionID,
+		}, nil
+	})
+	messages.EventsUnsubscribe.Register(router, func(rc *butlerd.RequestContext, params butlerd.EventsUnsubscribeParams) (*butlerd.EventsUnsubscribeResult, error) {
+		return &butlerd.EventsUnsubscribeResult{
+			DidUnsubscribe: router.UnsubscribeEvents(params.SubscriptionID),
+		}, nil
+	})
+}
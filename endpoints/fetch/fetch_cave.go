@@ -23,6 +23,9 @@ func FormatCave(conn *sqlite.Conn, cave *models.Cave) *butlerd.Cave {
 		return nil
 	}
 
+	// invalid overrides are left out here, Launch warns about them
+	launchOverrides, _ := butlerd.UnmarshalLaunchOverrides(cave.LaunchOverrides)
+
 	return &butlerd.Cave{
 		ID: cave.ID,
 
@@ -42,5 +45,7 @@ func FormatCave(conn *sqlite.Conn, cave *models.Cave) *butlerd.Cave {
 			LastTouchedAt: cave.LastTouchedAt,
 			SecondsRun:    cave.SecondsRun,
 		},
+
+		LaunchOverrides: launchOverrides,
 	}
 }
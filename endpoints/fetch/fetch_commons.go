@@ -61,8 +61,9 @@ func FetchCommons(rc *butlerd.RequestContext, params butlerd.FetchCommonsParams)
 
 func FormatInstallLocation(conn *sqlite.Conn, consumer *state.Consumer, il *models.InstallLocation) *butlerd.InstallLocationSummary {
 	sum := &butlerd.InstallLocationSummary{
-		ID:   il.ID,
-		Path: il.Path,
+		ID:             il.ID,
+		Path:           il.Path,
+		VerifyInstalls: il.VerifyInstalls,
 		SizeInfo: &butlerd.InstallLocationSizeInfo{
 			InstalledSize: -1,
 			FreeSize:      -1,
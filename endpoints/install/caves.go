@@ -4,6 +4,9 @@ import (
 	"crawshaw.io/sqlite"
 	"github.com/itchio/butler/butlerd"
 	"github.com/itchio/butler/database/models"
+	"github.com/itchio/hades"
+	"github.com/pkg/errors"
+	"xorm.io/builder"
 )
 
 func CavesSetPinned(rc *butlerd.RequestContext, params butlerd.CavesSetPinnedParams) (*butlerd.CavesSetPinnedResult, error) {
@@ -15,3 +18,31 @@ func CavesSetPinned(rc *butlerd.RequestContext, params butlerd.CavesSetPinnedPar
 
 	return &butlerd.CavesSetPinnedResult{}, nil
 }
+
+func CavesSetLaunchOverrides(rc *butlerd.RequestContext, params butlerd.CavesSetLaunchOverridesParams) (*butlerd.CavesSetLaunchOverridesResult, error) {
+	var overrides models.JSON
+	err := butlerd.MarshalLaunchOverrides(params.Overrides, &overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	var found bool
+	rc.WithConn(func(conn *sqlite.Conn) {
+		cave := models.CaveByID(conn, params.CaveID)
+		if cave == nil {
+			return
+		}
+		found = true
+		// a launch in progress may save the cave's play time, only
+		// touch the overrides so neither write clobbers the other
+		models.MustUpdate(conn, &models.Cave{},
+			hades.Where(builder.Eq{"id": cave.ID}),
+			builder.Eq{"launch_overrides": overrides},
+		)
+	})
+	if !found {
+		return nil, errors.Errorf("cave (%s) not found", params.CaveID)
+	}
+
+	return &butlerd.CavesSetLaunchOverridesResult{}, nil
+}
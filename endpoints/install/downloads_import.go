@@ -0,0 +1,121 @@
+package install
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"crawshaw.io/sqlite"
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/cmd/operate"
+	"github.com/itchio/butler/database/models"
+	itchio "github.com/itchio/go-itchio"
+	"github.com/pkg/errors"
+)
+
+// DownloadsImport lives here rather than in the downloads package
+// because it needs InstallQueue, which depends on the downloads package.
+func DownloadsImport(rc *butlerd.RequestContext, params butlerd.DownloadsImportParams) (*butlerd.DownloadsImportResult, error) {
+	consumer := rc.Consumer
+
+	payload, err := ioutil.ReadFile(params.Path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var manifest butlerd.DownloadManifest
+	err = json.Unmarshal(payload, &manifest)
+	if err != nil {
+		return nil, errors.WithMessage(err, "parsing download manifest")
+	}
+	if manifest.Version != 1 {
+		return nil, errors.Errorf("Unsupported download manifest version %d", manifest.Version)
+	}
+
+	res := &butlerd.DownloadsImportResult{
+		Items:  []*butlerd.InstallQueueResult{},
+		Failed: []*butlerd.DownloadImportFailure{},
+	}
+
+	consumer.Infof("Importing %d downloads from (%s)", len(manifest.Entries), params.Path)
+	for _, entry := range manifest.Entries {
+		item, err := importDownload(rc, params, entry)
+		if err != nil {
+			consumer.Warnf("Could not queue game %d, upload %d: %v", entry.GameID, entry.UploadID, err)
+			res.Failed = append(res.Failed, &butlerd.DownloadImportFailure{
+				Entry: entry,
+				Error: err.Error(),
+			})
+			continue
+		}
+		res.Items = append(res.Items, item)
+	}
+	consumer.Infof("Queued %d downloads, %d failed", len(res.Items), len(res.Failed))
+
+	return res, nil
+}
+
+func importDownload(rc *butlerd.RequestContext, params butlerd.DownloadsImportParams, entry *butlerd.DownloadManifestEntry) (*butlerd.InstallQueueResult, error) {
+	if entry == nil {
+		return nil, errors.New("Empty manifest entry")
+	}
+
+	var access *operate.GameAccess
+	var cave *models.Cave
+	rc.WithConn(func(conn *sqlite.Conn) {
+		access = operate.AccessForGameID(conn, entry.GameID)
+		if entry.CaveID != "" {
+			cave = models.CaveByID(conn, entry.CaveID)
+		}
+	})
+	client := rc.Client(access.APIKey)
+
+	gameRes, err := client.GetGame(rc.Ctx, itchio.GetGameParams{
+		GameID:      entry.GameID,
+		Credentials: access.Credentials,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "fetching game")
+	}
+
+	uploadRes, err := client.GetUpload(rc.Ctx, itchio.GetUploadParams{
+		UploadID:    entry.UploadID,
+		Credentials: access.Credentials,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "fetching upload")
+	}
+
+	var build *itchio.Build
+	if entry.BuildID != 0 {
+		buildRes, err := client.GetBuild(rc.Ctx, itchio.GetBuildParams{
+			BuildID:     entry.BuildID,
+			Credentials: access.Credentials,
+		})
+		if err != nil {
+			return nil, errors.WithMessage(err, "fetching build")
+		}
+		build = buildRes.Build
+	}
+
+	queueParams := butlerd.InstallQueueParams{
+		Game:          gameRes.Game,
+		Upload:        uploadRes.Upload,
+		Build:         build,
+		Reason:        entry.Reason,
+		QueueDownload: true,
+	}
+
+	if cave != nil && cave.GameID == entry.GameID {
+		// importing on the machine it was exported from
+		queueParams.CaveID = cave.ID
+	} else {
+		// caves are local, this is a fresh install here
+		queueParams.Reason = butlerd.DownloadReasonInstall
+		queueParams.InstallLocationID = entry.InstallLocationID
+		if params.InstallLocationID != "" {
+			queueParams.InstallLocationID = params.InstallLocationID
+		}
+	}
+
+	return InstallQueue(rc, queueParams)
+}
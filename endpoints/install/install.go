@@ -18,7 +18,10 @@ func Register(router *butlerd.Router) {
 	messages.InstallLocationsAdd.Register(router, InstallLocationsAdd)
 	messages.InstallLocationsRemove.Register(router, InstallLocationsRemove)
 	messages.InstallLocationsScan.Register(router, InstallLocationsScan)
+	messages.InstallLocationsSetVerifyInstalls.Register(router, InstallLocationsSetVerifyInstalls)
 	messages.InstallCreateShortcut.Register(router, InstallCreateShortcut)
+	messages.DownloadsImport.Register(router, DownloadsImport)
 
 	messages.CavesSetPinned.Register(router, CavesSetPinned)
+	messages.CavesSetLaunchOverrides.Register(router, CavesSetLaunchOverrides)
 }
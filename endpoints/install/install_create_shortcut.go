@@ -2,25 +2,71 @@ package install
 
 import (
 	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
 
 	"crawshaw.io/sqlite"
 	"github.com/itchio/butler/butlerd"
 	"github.com/itchio/butler/database/models"
 	"github.com/itchio/butler/endpoints/install/shortcut"
+	"github.com/pkg/errors"
 )
 
+var dbPath string
+var dbPathLock sync.Mutex
+
+// SetDBPath sets the database shortcuts launch caves from, when they
+// run butler instead of opening an itch:// URL. It should be the one
+// butlerd is using.
+func SetDBPath(path string) {
+	dbPathLock.Lock()
+	defer dbPathLock.Unlock()
+	dbPath = path
+}
+
+func getDBPath() string {
+	dbPathLock.Lock()
+	defer dbPathLock.Unlock()
+	return dbPath
+}
+
 func InstallCreateShortcut(rc *butlerd.RequestContext, params butlerd.InstallCreateShortcutParams) (*butlerd.InstallCreateShortcutResult, error) {
 	var cave *models.Cave
+	var installFolder string
 
 	rc.WithConn(func(conn *sqlite.Conn) {
 		cave = models.CaveByID(conn, params.CaveID)
 		models.PreloadCaves(conn, cave)
+		installFolder = cave.GetInstallFolder(conn)
 	})
 	url := fmt.Sprintf("itch://caves/%s/launch", cave.ID)
 
-	err := shortcut.Create(shortcut.CreateParams{
+	butlerPath, err := os.Executable()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	command := []string{butlerPath}
+	if dbPath := getDBPath(); dbPath != "" {
+		command = append(command, "--dbpath", dbPath)
+	}
+	command = append(command, "launch", cave.ID)
+
+	iconSource, err := fetchShortcutIcon(rc, cave, installFolder)
+	if err != nil {
+		rc.Consumer.Warnf("Could not get shortcut icon: %v", err)
+	}
+
+	err = shortcut.Create(shortcut.CreateParams{
+		ID:          shortcutID(cave),
 		DisplayName: cave.Game.Title,
+		IconSource:  iconSource,
 		URL:         url,
+		Command:     command,
 		Consumer:    rc.Consumer,
 	})
 	if err != nil {
@@ -30,3 +76,53 @@ func InstallCreateShortcut(rc *butlerd.RequestContext, params butlerd.InstallCre
 	res := &butlerd.InstallCreateShortcutResult{}
 	return res, nil
 }
+
+func shortcutID(cave *models.Cave) string {
+	return fmt.Sprintf("cave-%s", cave.ID)
+}
+
+// fetchShortcutIcon downloads the game's cover into the install folder,
+// so it goes away on uninstall, and returns its path. It returns an empty
+// string if the game has no cover.
+func fetchShortcutIcon(rc *butlerd.RequestContext, cave *models.Cave, installFolder string) (string, error) {
+	coverURL := cave.Game.StillCoverURL
+	if coverURL == "" {
+		coverURL = cave.Game.CoverURL
+	}
+	if coverURL == "" {
+		return "", nil
+	}
+
+	ext := strings.ToLower(path.Ext(strings.SplitN(coverURL, "?", 2)[0]))
+	if ext == "" {
+		ext = ".png"
+	}
+	iconPath := filepath.Join(installFolder, ".itch", "cover"+ext)
+
+	res, err := rc.HTTPClient.Get(coverURL)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", errors.Errorf("HTTP %d while downloading cover (%s)", res.StatusCode, coverURL)
+	}
+
+	err = os.MkdirAll(filepath.Dir(iconPath), 0o755)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	f, err := os.Create(iconPath)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, res.Body)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return iconPath, nil
+}
@@ -255,7 +255,6 @@ func InstallQueue(rc *butlerd.RequestContext, queueParams butlerd.InstallQueuePa
 
 	}
 
-	success = true
 	res := &butlerd.InstallQueueResult{
 		ID:                id,
 		CaveID:            params.CaveID,
@@ -277,6 +276,7 @@ func InstallQueue(rc *butlerd.RequestContext, queueParams butlerd.InstallQueuePa
 		}
 	}
 
+	success = true
 	return res, nil
 }
 
@@ -96,8 +96,9 @@ func InstallLocationsAdd(rc *butlerd.RequestContext, params butlerd.InstallLocat
 	}
 
 	il := &models.InstallLocation{
-		ID:   params.ID,
-		Path: params.Path,
+		ID:             params.ID,
+		Path:           params.Path,
+		VerifyInstalls: params.VerifyInstalls,
 	}
 	models.MustSave(conn, il)
 
@@ -148,3 +149,21 @@ func InstallLocationsRemove(rc *butlerd.RequestContext, params butlerd.InstallLo
 	res := &butlerd.InstallLocationsRemoveResult{}
 	return res, nil
 }
+
+func InstallLocationsSetVerifyInstalls(rc *butlerd.RequestContext, params butlerd.InstallLocationsSetVerifyInstallsParams) (*butlerd.InstallLocationsSetVerifyInstallsResult, error) {
+	conn := rc.GetConn()
+	defer rc.PutConn(conn)
+
+	il := models.InstallLocationByID(conn, params.ID)
+	if il == nil {
+		return nil, errors.Errorf("install location (%s) not found", params.ID)
+	}
+
+	il.VerifyInstalls = params.Enabled
+	models.MustSave(conn, il)
+
+	res := &butlerd.InstallLocationsSetVerifyInstallsResult{
+		InstallLocation: fetch.FormatInstallLocation(conn, rc.Consumer, il),
+	}
+	return res, nil
+}
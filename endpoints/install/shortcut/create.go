@@ -3,6 +3,9 @@ package shortcut
 import "github.com/itchio/headway/state"
 
 type CreateParams struct {
+	// Identifies the shortcut, so it can be removed later
+	ID string
+
 	// What the user should see
 	DisplayName string
 
@@ -12,6 +15,18 @@ type CreateParams struct {
 	// What the shortcut should open
 	URL string
 
+	// Command the shortcut should run, on platforms where
+	// shortcuts can't open URLs
+	Command []string
+
+	// For logging
+	Consumer *state.Consumer
+}
+
+type RemoveParams struct {
+	// Same as CreateParams.ID
+	ID string
+
 	// For logging
 	Consumer *state.Consumer
 }
@@ -0,0 +1,139 @@
+// +build linux
+
+package shortcut
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// Create writes a freedesktop desktop entry, so the shortcut shows up
+// in application menus and launchers.
+// See https://specifications.freedesktop.org/desktop-entry-spec/latest/
+func Create(params CreateParams) error {
+	err := validation.ValidateStruct(&params,
+		validation.Field(&params.ID, validation.Required),
+		validation.Field(&params.DisplayName, validation.Required),
+		validation.Field(&params.Command, validation.Required),
+		validation.Field(&params.Consumer, validation.Required),
+	)
+	if err != nil {
+		return err
+	}
+
+	consumer := params.Consumer
+
+	applicationsPath := getApplicationsPath()
+	err = os.MkdirAll(applicationsPath, 0o755)
+	if err != nil {
+		return err
+	}
+
+	shortcutPath := filepath.Join(applicationsPath, desktopFileName(params.ID))
+	// some desktop environments only trust executable desktop entries
+	err = ioutil.WriteFile(shortcutPath, []byte(desktopEntry(params)), 0o755)
+	if err != nil {
+		return err
+	}
+	consumer.Infof("Created shortcut (%s)", shortcutPath)
+
+	return nil
+}
+
+func Remove(params RemoveParams) error {
+	err := validation.ValidateStruct(&params,
+		validation.Field(&params.ID, validation.Required),
+		validation.Field(&params.Consumer, validation.Required),
+	)
+	if err != nil {
+		return err
+	}
+
+	shortcutPath := filepath.Join(getApplicationsPath(), desktopFileName(params.ID))
+	err = os.Remove(shortcutPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	params.Consumer.Infof("Removed shortcut (%s)", shortcutPath)
+
+	return nil
+}
+
+func getApplicationsPath() string {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		dataHome = filepath.Join(os.Getenv("HOME"), ".local", "share")
+	}
+	return filepath.Join(dataHome, "applications")
+}
+
+func desktopFileName(id string) string {
+	return fmt.Sprintf("io.itch.%s.desktop", sanitizeID(id))
+}
+
+// desktop file IDs should only contain letters, digits, '-', '_' and '.'
+func sanitizeID(id string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, id)
+}
+
+func desktopEntry(params CreateParams) string {
+	var lines = []string{
+		"[Desktop Entry]",
+		"Type=Application",
+		"Version=1.0",
+		fmt.Sprintf("Name=%s", escapeString(params.DisplayName)),
+		fmt.Sprintf("Exec=%s", escapeString(execLine(params.Command))),
+	}
+	if params.IconSource != "" {
+		lines = append(lines, fmt.Sprintf("Icon=%s", escapeString(params.IconSource)))
+	}
+	lines = append(lines,
+		"Terminal=false",
+		"Categories=Game;",
+	)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// execLine quotes arguments as the Exec key requires. The result
+// still needs to be escaped like any other string value.
+func execLine(command []string) string {
+	var args []string
+	for _, arg := range command {
+		arg = strings.ReplaceAll(arg, "%", "%%")
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'\\><~|&;$*?#()`=") {
+			var quoted strings.Builder
+			for _, r := range arg {
+				if strings.ContainsRune("\"`$\\", r) {
+					quoted.WriteRune('\\')
+				}
+				quoted.WriteRune(r)
+			}
+			arg = fmt.Sprintf("\"%s\"", quoted.String())
+		}
+		args = append(args, arg)
+	}
+	return strings.Join(args, " ")
+}
+
+func escapeString(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "\n", "\\n")
+	s = strings.ReplaceAll(s, "\t", "\\t")
+	s = strings.ReplaceAll(s, "\r", "\\r")
+	return s
+}
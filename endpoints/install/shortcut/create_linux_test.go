@@ -0,0 +1,77 @@
+// +build linux
+
+package shortcut
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/itchio/headway/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecLine(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.EqualValues(`/usr/bin/butler launch abc`, execLine([]string{"/usr/bin/butler", "launch", "abc"}))
+	assert.EqualValues(`"/home/user/My Games/butler" launch abc`, execLine([]string{"/home/user/My Games/butler", "launch", "abc"}))
+	assert.EqualValues(`"a \"quoted\" \$arg"`, execLine([]string{`a "quoted" $arg`}))
+	assert.EqualValues(`100%%`, execLine([]string{"100%"}))
+	assert.EqualValues(`""`, execLine([]string{""}))
+}
+
+func Test_CreateRemove(t *testing.T) {
+	assert := assert.New(t)
+
+	dataHome, err := ioutil.TempDir("", "shortcut-test")
+	must(t, err)
+	defer os.RemoveAll(dataHome)
+
+	oldDataHome := os.Getenv("XDG_DATA_HOME")
+	os.Setenv("XDG_DATA_HOME", dataHome)
+	defer os.Setenv("XDG_DATA_HOME", oldDataHome)
+
+	consumer := &state.Consumer{}
+	err = Create(CreateParams{
+		ID:          "cave-1234",
+		DisplayName: "Jedi Mindset: The Revenge",
+		IconSource:  "/games/jedi/.itch/cover.png",
+		Command:     []string{"/opt/butler dir/butler", "launch", "1234"},
+		Consumer:    consumer,
+	})
+	must(t, err)
+
+	shortcutPath := filepath.Join(dataHome, "applications", "io.itch.cave-1234.desktop")
+	contents, err := ioutil.ReadFile(shortcutPath)
+	must(t, err)
+	assert.EqualValues(`[Desktop Entry]
+Type=Application
+Version=1.0
+Name=Jedi Mindset: The Revenge
+Exec="/opt/butler dir/butler" launch 1234
+Icon=/games/jedi/.itch/cover.png
+Terminal=false
+Categories=Game;
+`, string(contents))
+
+	must(t, Remove(RemoveParams{
+		ID:       "cave-1234",
+		Consumer: consumer,
+	}))
+	_, err = os.Stat(shortcutPath)
+	assert.True(os.IsNotExist(err))
+
+	// removing twice is fine
+	must(t, Remove(RemoveParams{
+		ID:       "cave-1234",
+		Consumer: consumer,
+	}))
+}
+
+func must(t *testing.T, err error) {
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+}
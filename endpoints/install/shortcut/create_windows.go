@@ -4,20 +4,23 @@ package shortcut
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 
-	"github.com/go-ole/go-ole"
-	"github.com/go-ole/go-ole/oleutil"
 	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/itchio/headway/state"
 	"github.com/itchio/ox/winox"
-	"github.com/scjalliance/comshim"
 )
 
+// Create writes an internet shortcut to the start menu. It's named after
+// the game, so that's what shows up in the start menu, and records params.ID
+// so it can be found again if the game is renamed, see Remove.
 func Create(params CreateParams) error {
 	err := validation.ValidateStruct(&params,
+		validation.Field(&params.ID, validation.Required),
 		validation.Field(&params.DisplayName, validation.Required),
 		validation.Field(&params.URL, validation.Required),
 		validation.Field(&params.Consumer, validation.Required),
@@ -26,52 +29,140 @@ func Create(params CreateParams) error {
 		return err
 	}
 
+	itchCorpPath, err := getItchCorpPath()
+	if err != nil {
+		return err
+	}
+	return createIn(itchCorpPath, params)
+}
+
+func createIn(itchCorpPath string, params CreateParams) error {
 	consumer := params.Consumer
 
-	startMenuPath, err := winox.GetFolderPath(winox.FolderTypePrograms)
+	err := os.MkdirAll(itchCorpPath, 0o755)
 	if err != nil {
 		return err
 	}
 
-	itchCorpPath := filepath.Join(startMenuPath, "Itch Corp")
-	err = os.MkdirAll(itchCorpPath, 0o755)
+	// don't leave a shortcut with the game's previous name behind
+	err = removeIn(itchCorpPath, params.ID, consumer)
 	if err != nil {
 		return err
 	}
 
-	shortcutName := fmt.Sprintf("%s.url", sanitizeFileName(params.DisplayName))
-	shortcutPath := filepath.Join(itchCorpPath, shortcutName)
-
-	comshim.Add(1)
-	defer comshim.Done()
+	shortcutPath := filepath.Join(itchCorpPath, shortcutFileName(params.DisplayName, ""))
+	if _, err := os.Stat(shortcutPath); err == nil {
+		// another game has the same name
+		shortcutPath = filepath.Join(itchCorpPath, shortcutFileName(params.DisplayName, params.ID))
+	}
 
-	oleShellObject, err := oleutil.CreateObject("WScript.Shell")
+	err = ioutil.WriteFile(shortcutPath, []byte(internetShortcut(params)), 0o644)
 	if err != nil {
 		return err
 	}
-	defer oleShellObject.Release()
+	consumer.Infof("Created shortcut (%s)", shortcutPath)
+
+	return nil
+}
 
-	wshell, err := oleShellObject.QueryInterface(ole.IID_IDispatch)
+// Remove removes every shortcut created with params.ID, whatever
+// the game was called at the time.
+func Remove(params RemoveParams) error {
+	err := validation.ValidateStruct(&params,
+		validation.Field(&params.ID, validation.Required),
+		validation.Field(&params.Consumer, validation.Required),
+	)
 	if err != nil {
 		return err
 	}
-	defer wshell.Release()
 
-	cs, err := oleutil.CallMethod(wshell, "CreateShortcut", shortcutPath)
+	itchCorpPath, err := getItchCorpPath()
 	if err != nil {
 		return err
 	}
-	idispatch := cs.ToIDispatch()
-	oleutil.PutProperty(idispatch, "TargetPath", params.URL)
-	_, err = oleutil.CallMethod(idispatch, "Save")
+	return removeIn(itchCorpPath, params.ID, params.Consumer)
+}
+
+func removeIn(itchCorpPath string, id string, consumer *state.Consumer) error {
+	entries, err := ioutil.ReadDir(itchCorpPath)
 	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
 		return err
 	}
-	consumer.Infof("Created shortcut (%s)", shortcutPath)
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".url") {
+			continue
+		}
+
+		shortcutPath := filepath.Join(itchCorpPath, entry.Name())
+		if readShortcutID(shortcutPath) != id {
+			continue
+		}
+
+		err = os.Remove(shortcutPath)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		consumer.Infof("Removed shortcut (%s)", shortcutPath)
+	}
 
 	return nil
 }
 
+func getItchCorpPath() (string, error) {
+	startMenuPath, err := winox.GetFolderPath(winox.FolderTypePrograms)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(startMenuPath, "Itch Corp"), nil
+}
+
+func shortcutFileName(displayName string, id string) string {
+	name := sanitizeFileName(displayName)
+	if id != "" {
+		name = fmt.Sprintf("%s (%s)", name, sanitizeFileName(id))
+	}
+	return fmt.Sprintf("%s.url", name)
+}
+
+// internetShortcut returns the contents of a .url file. Windows ignores
+// sections it doesn't know about, so the shortcut ID is stored in its own.
+func internetShortcut(params CreateParams) string {
+	var lines = []string{
+		"[InternetShortcut]",
+		fmt.Sprintf("URL=%s", params.URL),
+		"",
+		"[itch]",
+		fmt.Sprintf("ShortcutID=%s", params.ID),
+	}
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+// readShortcutID returns the ID a .url file was created with, or an
+// empty string if it wasn't created by Create.
+func readShortcutID(shortcutPath string) string {
+	contents, err := ioutil.ReadFile(shortcutPath)
+	if err != nil {
+		return ""
+	}
+
+	inSection := false
+	for _, line := range strings.Split(string(contents), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			inSection = line == "[itch]"
+			continue
+		}
+		if inSection && strings.HasPrefix(line, "ShortcutID=") {
+			return strings.TrimPrefix(line, "ShortcutID=")
+		}
+	}
+	return ""
+}
+
 var anyAmountOfSpaces = regexp.MustCompile(`\s+`)
 
 func sanitizeFileName(s string) string {
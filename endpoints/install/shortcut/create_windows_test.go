@@ -3,8 +3,13 @@
 package shortcut
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/itchio/headway/state"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,3 +23,56 @@ func Test_SanitizeFileName(t *testing.T) {
 	assert.EqualValues("CON_", sanitizeFileName("CON"))
 	assert.EqualValues("con_", sanitizeFileName("con"))
 }
+
+func Test_ShortcutsByID(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "shortcuts")
+	must(t, err)
+	defer os.RemoveAll(dir)
+
+	consumer := &state.Consumer{}
+	create := func(id string, displayName string) {
+		must(t, createIn(dir, CreateParams{
+			ID:          id,
+			DisplayName: displayName,
+			URL:         fmt.Sprintf("itch://caves/%s/launch", id),
+			Consumer:    consumer,
+		}))
+	}
+	names := func() []string {
+		entries, err := ioutil.ReadDir(dir)
+		must(t, err)
+		var res []string
+		for _, entry := range entries {
+			res = append(res, entry.Name())
+		}
+		return res
+	}
+
+	create("cave-1", "Jedi Mindset: The Revenge")
+	assert.EqualValues([]string{"Jedi Mindset The Revenge.url"}, names())
+	assert.EqualValues("cave-1", readShortcutID(filepath.Join(dir, "Jedi Mindset The Revenge.url")))
+
+	// renamed games don't leave their old shortcut behind
+	create("cave-1", "Jedi Mindset")
+	assert.EqualValues([]string{"Jedi Mindset.url"}, names())
+
+	// games with the same name each get a shortcut
+	create("cave-2", "Jedi Mindset")
+	assert.EqualValues([]string{"Jedi Mindset (cave-2).url", "Jedi Mindset.url"}, names())
+
+	must(t, removeIn(dir, "cave-1", consumer))
+	assert.EqualValues([]string{"Jedi Mindset (cave-2).url"}, names())
+
+	// shortcuts not created by us are left alone
+	must(t, ioutil.WriteFile(filepath.Join(dir, "Other.url"), []byte("[InternetShortcut]\r\nURL=https://itch.io\r\n"), 0o644))
+	must(t, removeIn(dir, "cave-2", consumer))
+	assert.EqualValues([]string{"Other.url"}, names())
+}
+
+func must(t *testing.T, err error) {
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+}
@@ -1,17 +1,36 @@
 package install
 
 import (
+	"crawshaw.io/sqlite"
 	"github.com/itchio/butler/butlerd"
 	"github.com/itchio/butler/cmd/operate"
+	"github.com/itchio/butler/database/models"
+	"github.com/itchio/butler/endpoints/install/shortcut"
 	"github.com/pkg/errors"
 )
 
 func UninstallPerform(rc *butlerd.RequestContext, params butlerd.UninstallPerformParams) (*butlerd.UninstallPerformResult, error) {
+	// the cave is gone once uninstalled, grab it to remove its shortcut
+	var cave *models.Cave
+	rc.WithConn(func(conn *sqlite.Conn) {
+		cave = models.CaveByID(conn, params.CaveID)
+	})
+
 	err := operate.UninstallPerform(rc.Ctx, rc, params)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
+	if cave != nil {
+		err = shortcut.Remove(shortcut.RemoveParams{
+			ID:       shortcutID(cave),
+			Consumer: rc.Consumer,
+		})
+		if err != nil {
+			rc.Consumer.Warnf("Could not remove shortcut: %v", err)
+		}
+	}
+
 	res := &butlerd.UninstallPerformResult{}
 	return res, nil
 }
@@ -0,0 +1,85 @@
+package launch
+
+import (
+	"time"
+
+	"crawshaw.io/sqlite"
+	"github.com/google/uuid"
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/database/models"
+	"github.com/pkg/errors"
+)
+
+func HostsList(rc *butlerd.RequestContext, params butlerd.HostsListParams) (*butlerd.HostsListResult, error) {
+	res := &butlerd.HostsListResult{
+		Hosts: []*butlerd.CustomHost{},
+	}
+
+	rc.WithConn(func(conn *sqlite.Conn) {
+		for _, ch := range models.AllCustomHosts(conn) {
+			fh, err := formatCustomHost(ch)
+			if err != nil {
+				rc.Consumer.Warnf("Ignoring custom host (%s): %v", ch.ID, err)
+				continue
+			}
+			res.Hosts = append(res.Hosts, fh)
+		}
+	})
+	return res, nil
+}
+
+func HostsAdd(rc *butlerd.RequestContext, params butlerd.HostsAddParams) (*butlerd.HostsAddResult, error) {
+	host := params.Host
+	ch := &models.CustomHost{
+		ID:        uuid.New().String(),
+		Name:      params.Name,
+		CreatedAt: time.Now().UTC(),
+	}
+	err := butlerd.EncodeCustomHost(ch, host)
+	if err != nil {
+		return nil, err
+	}
+	rc.WithConn(ch.Save)
+	rc.Consumer.Statf("Added custom host (%s): %v", ch.ID, host)
+
+	fh, err := formatCustomHost(ch)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &butlerd.HostsAddResult{
+		Host: fh,
+	}
+	return res, nil
+}
+
+func HostsRemove(rc *butlerd.RequestContext, params butlerd.HostsRemoveParams) (*butlerd.HostsRemoveResult, error) {
+	var ch *models.CustomHost
+	rc.WithConn(func(conn *sqlite.Conn) {
+		ch = models.CustomHostByID(conn, params.ID)
+		if ch != nil {
+			ch.Delete(conn)
+		}
+	})
+	if ch == nil {
+		return nil, errors.Errorf("custom host (%s) not found", params.ID)
+	}
+	rc.Consumer.Statf("Removed custom host (%s)", params.ID)
+
+	res := &butlerd.HostsRemoveResult{}
+	return res, nil
+}
+
+func formatCustomHost(ch *models.CustomHost) (*butlerd.CustomHost, error) {
+	host, err := butlerd.DecodeCustomHost(ch)
+	if err != nil {
+		return nil, err
+	}
+
+	return &butlerd.CustomHost{
+		ID:        ch.ID,
+		Name:      ch.Name,
+		Host:      host,
+		CreatedAt: ch.CreatedAt,
+	}, nil
+}
@@ -3,6 +3,7 @@ package launch
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -26,10 +27,15 @@ var ErrCandidateDisappeared = goerrors.New("candidate disappeared from disk!")
 
 func Register(router *butlerd.Router) {
 	messages.Launch.Register(router, Launch)
+	messages.LaunchSessionsList.Register(router, LaunchSessionsList)
+	messages.HostsList.Register(router, HostsList)
+	messages.HostsAdd.Register(router, HostsAdd)
+	messages.HostsRemove.Register(router, HostsRemove)
 }
 
 func Launch(rc *butlerd.RequestContext, params butlerd.LaunchParams) (*butlerd.LaunchResult, error) {
 	consumer := rc.Consumer
+	rc.SetEventScope(butlerd.EventScope{CaveID: params.CaveID})
 	var res *butlerd.LaunchResult
 
 	err := withInstallFolderLock(withInstallFolderLockParams{
@@ -52,6 +58,12 @@ func Launch(rc *butlerd.RequestContext, params butlerd.LaunchParams) (*butlerd.L
 			return errors.WithStack(err)
 		}
 
+		overrides, err := butlerd.UnmarshalLaunchOverrides(cave.LaunchOverrides)
+		if err != nil {
+			consumer.Warnf("Ignoring invalid launch overrides: %v", err)
+			overrides = nil
+		}
+
 		hosts, err := rc.HostEnumerator().Enumerate(rc.Consumer)
 		if err != nil {
 			return err
@@ -64,7 +76,7 @@ func Launch(rc *butlerd.RequestContext, params butlerd.LaunchParams) (*butlerd.L
 		if err != nil {
 			return err
 		}
-		targets := targetRes.targets
+		targets := preferredTargets(consumer, targetRes.targets, overrides)
 
 		var target *butlerd.LaunchTarget
 		if len(targets) == 0 {
@@ -73,6 +85,10 @@ func Launch(rc *butlerd.RequestContext, params butlerd.LaunchParams) (*butlerd.L
 			consumer.Infof("Single target, picking it:")
 			target = targets[0]
 			consumer.Logf("%s", target.Strategy.String())
+		} else if remembered := rememberedTarget(consumer, targets, overrides); remembered != nil {
+			consumer.Infof("Found (%d) targets, using remembered one:", len(targets))
+			target = remembered
+			consumer.Logf("%s", target.Strategy.String())
 		} else {
 			consumer.Infof("Found (%d) targets, asking client to pick via PickManifestAction", len(targets))
 			var actions []*manifest.Action
@@ -96,6 +112,10 @@ func Launch(rc *butlerd.RequestContext, params butlerd.LaunchParams) (*butlerd.L
 			target = targets[r.Index]
 			consumer.Infof("Target picked:")
 			consumer.Logf("%s", target.Strategy.String())
+
+			if overrides != nil && overrides.RememberAction {
+				rememberTarget(rc, cave, overrides, int64(r.Index), target)
+			}
 		}
 
 		consumer.Infof("→ Using strategy (%s)", target.Strategy.Strategy)
@@ -115,6 +135,20 @@ func Launch(rc *butlerd.RequestContext, params butlerd.LaunchParams) (*butlerd.L
 		args = append(args, target.Action.Args...)
 		fullTargetPath := target.Strategy.FullTargetPath
 
+		if overrides != nil {
+			args = append(args, overrides.Args...)
+			for k, v := range overrides.Env {
+				env[k] = v
+			}
+			if overrides.WorkingDirectory != "" {
+				workingDirectory = overrides.WorkingDirectory
+				if !filepath.IsAbs(workingDirectory) {
+					workingDirectory = filepath.Join(installFolder, workingDirectory)
+				}
+				consumer.Infof("  working directory (%s)", workingDirectory)
+			}
+		}
+
 		err = requestAPIKeyIfNecessary(rc, target.Action, game, access, env)
 		if err != nil {
 			return errors.WithMessage(err, "While requesting API key")
@@ -170,7 +204,7 @@ func Launch(rc *butlerd.RequestContext, params butlerd.LaunchParams) (*butlerd.L
 				session = res.UserGameSession
 
 				cave.UpdateInteractions(res.Summary)
-				rc.WithConn(cave.Save)
+				rc.WithConn(cave.SaveInteractions)
 
 				return
 			}
@@ -193,7 +227,7 @@ func Launch(rc *butlerd.RequestContext, params butlerd.LaunchParams) (*butlerd.L
 				session = res.UserGameSession
 
 				cave.UpdateInteractions(res.Summary)
-				rc.WithConn(cave.Save)
+				rc.WithConn(cave.SaveInteractions)
 
 				return
 			}
@@ -244,6 +278,8 @@ func Launch(rc *butlerd.RequestContext, params butlerd.LaunchParams) (*butlerd.L
 
 		go sessionWatcher()
 
+		recorder := recordLaunchSession(rc, cave, target)
+
 		launcherParams := LauncherParams{
 			RequestContext: rc,
 			Ctx:            rc.Ctx,
@@ -253,6 +289,8 @@ func Launch(rc *butlerd.RequestContext, params butlerd.LaunchParams) (*butlerd.L
 			AppManifest:      targetRes.appManifest,
 			Action:           target.Action,
 			Sandbox:          sandbox,
+			SandboxBackend:   params.SandboxBackend,
+			SandboxNoNetwork: params.SandboxNoNetwork,
 			WorkingDirectory: workingDirectory,
 			Args:             args,
 			Env:              env,
@@ -260,22 +298,25 @@ func Launch(rc *butlerd.RequestContext, params butlerd.LaunchParams) (*butlerd.L
 			PrereqsDir:    params.PrereqsDir,
 			ForcePrereqs:  params.ForcePrereqs,
 			Access:        access,
+			GameID:        cave.GameID,
 			InstallFolder: installFolder,
 			Host:          target.Host,
 
 			SessionStarted: func() {
 				startSessionOnce.Do(func() {
+					recorder.Started()
 					close(sessionStartedChan)
 				})
 			},
+			SessionExited: recorder.Exited,
 		}
 
 		err = launcher.Do(launcherParams)
-		close(sessionEndedChan)
 		if err != nil {
 			crashed = true
-			return err
 		}
+		close(sessionEndedChan)
+		recorder.Ended(err)
 
 		consumer.Debugf("Waiting on session watcher...")
 		sessionCancel()
@@ -286,6 +327,10 @@ func Launch(rc *butlerd.RequestContext, params butlerd.LaunchParams) (*butlerd.L
 			consumer.Warnf("Timed out waiting on session watcher")
 		}
 
+		if err != nil {
+			return err
+		}
+
 		res = &butlerd.LaunchResult{}
 		return nil
 	})
@@ -0,0 +1,142 @@
+package launch
+
+import (
+	"time"
+
+	"crawshaw.io/sqlite"
+	"github.com/google/uuid"
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/database/models"
+	"github.com/itchio/butler/endpoints/fetch/pager"
+	"github.com/itchio/hades"
+	"xorm.io/builder"
+)
+
+func LaunchSessionsList(rc *butlerd.RequestContext, params butlerd.LaunchSessionsListParams) (*butlerd.LaunchSessionsListResult, error) {
+	res := &butlerd.LaunchSessionsListResult{
+		Items: []*butlerd.LaunchSession{},
+	}
+
+	rc.WithConn(func(conn *sqlite.Conn) {
+		cond := builder.NewCond()
+		if params.CaveID != "" {
+			cond = cond.And(builder.Eq{"cave_id": params.CaveID})
+		}
+		if params.GameID != 0 {
+			cond = cond.And(builder.Eq{"game_id": params.GameID})
+		}
+		search := hades.Search{}.OrderBy("started_at " + pager.Ordering("DESC", params.Reverse))
+
+		var sessions []*models.LaunchSession
+		pg := pager.New(params)
+		res.NextCursor = pg.Fetch(conn, &sessions, cond, search)
+		models.PreloadLaunchSessions(conn, sessions)
+		for _, s := range sessions {
+			res.Items = append(res.Items, formatLaunchSession(rc, s))
+		}
+	})
+	return res, nil
+}
+
+func formatLaunchSession(rc *butlerd.RequestContext, s *models.LaunchSession) *butlerd.LaunchSession {
+	stdout, err := s.GetStdout()
+	if err != nil {
+		rc.Consumer.Warnf("Invalid stdout for launch session %s: %v", s.ID, err)
+	}
+	if stdout == nil {
+		stdout = []string{}
+	}
+
+	stderr, err := s.GetStderr()
+	if err != nil {
+		rc.Consumer.Warnf("Invalid stderr for launch session %s: %v", s.ID, err)
+	}
+	if stderr == nil {
+		stderr = []string{}
+	}
+
+	return &butlerd.LaunchSession{
+		ID:           s.ID,
+		CaveID:       s.CaveID,
+		Game:         s.Game,
+		Upload:       s.Upload,
+		Build:        s.Build,
+		StartedAt:    s.StartedAt,
+		EndedAt:      s.EndedAt,
+		Host:         s.Host,
+		Wrapper:      s.Wrapper,
+		Strategy:     butlerd.LaunchStrategy(s.Strategy),
+		ExitCode:     s.ExitCode,
+		Stdout:       stdout,
+		Stderr:       stderr,
+		ErrorMessage: s.ErrorMessage,
+	}
+}
+
+// A sessionRecorder keeps the launch_sessions table up to date
+// during a launch.
+type sessionRecorder struct {
+	rc      *butlerd.RequestContext
+	session *models.LaunchSession
+}
+
+// recordLaunchSession saves a session for a target that's about to be
+// launched, so there's a trace of it even if butler exits before the game.
+func recordLaunchSession(rc *butlerd.RequestContext, cave *models.Cave, target *butlerd.LaunchTarget) *sessionRecorder {
+	session := &models.LaunchSession{
+		ID:        uuid.New().String(),
+		CaveID:    cave.ID,
+		GameID:    cave.GameID,
+		UploadID:  cave.UploadID,
+		BuildID:   cave.BuildID,
+		StartedAt: time.Now().UTC(),
+		Host:      target.Host.Runtime.String(),
+		Strategy:  string(target.Strategy.Strategy),
+	}
+	if target.Host.Wrapper != nil {
+		session.Wrapper = target.Host.Wrapper.WrapperBinary
+	}
+
+	sr := &sessionRecorder{
+		rc:      rc,
+		session: session,
+	}
+	sr.save()
+	return sr
+}
+
+// Started is called when the game actually starts running
+func (sr *sessionRecorder) Started() {
+	sr.session.StartedAt = time.Now().UTC()
+	sr.save()
+}
+
+// Exited is called by launchers that run a process, once it has exited
+func (sr *sessionRecorder) Exited(exitCode int64, stdout []string, stderr []string) {
+	sr.session.ExitCode = &exitCode
+
+	err := sr.session.SetStdout(stdout)
+	if err != nil {
+		sr.rc.Consumer.Warnf("Could not record stdout: %v", err)
+	}
+	err = sr.session.SetStderr(stderr)
+	if err != nil {
+		sr.rc.Consumer.Warnf("Could not record stderr: %v", err)
+	}
+}
+
+// Ended is called once the launcher is done, with the error it
+// returned, if any
+func (sr *sessionRecorder) Ended(launchErr error) {
+	endedAt := time.Now().UTC()
+	sr.session.EndedAt = &endedAt
+	if launchErr != nil {
+		msg := launchErr.Error()
+		sr.session.ErrorMessage = &msg
+	}
+	sr.save()
+}
+
+func (sr *sessionRecorder) save() {
+	sr.rc.WithConn(sr.session.Save)
+}
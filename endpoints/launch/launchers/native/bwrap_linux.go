@@ -0,0 +1,199 @@
+// +build linux
+
+package native
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/itchio/smaug/runner"
+	"github.com/pkg/errors"
+)
+
+// bwrapRunner runs games with bubblewrap. The whole filesystem is
+// read-only, including the game's install folder, except for its
+// temporary folder. The user's home folder is replaced with a per-game
+// one, outside of the install folder so saves survive uninstalls, and
+// the XDG base directories point inside of it.
+type bwrapRunner struct {
+	params    runner.RunnerParams
+	noNetwork bool
+	gameHome  string
+}
+
+var _ runner.Runner = (*bwrapRunner)(nil)
+
+func newBwrapRunner(params runner.RunnerParams, gameID int64, noNetwork bool) (runner.Runner, error) {
+	env := envMap(params.Env)
+	if home := env["HOME"]; home == "" || home == "/" {
+		return nil, errors.Errorf("bubblewrap sandbox needs a home folder, HOME is (%s)", home)
+	}
+
+	br := &bwrapRunner{
+		params:    params,
+		noNetwork: noNetwork,
+		gameHome:  sandboxHome(env, gameID),
+	}
+	return br, nil
+}
+
+// sandboxHome is the folder that stands in for the user's home folder
+// for a sandboxed game, so files it writes there persist between launches
+func sandboxHome(env map[string]string, gameID int64) string {
+	return filepath.Join(xdgDir(env, "XDG_DATA_HOME", ".local/share"), "itch", "sandbox-homes", fmt.Sprintf("game-%d", gameID))
+}
+
+// xdgDir returns the value of an XDG base directory variable,
+// or its default relative to the home folder
+func xdgDir(env map[string]string, name string, fallback string) string {
+	if dir := env[name]; filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(env["HOME"], filepath.FromSlash(fallback))
+}
+
+// sandboxXDGDirs are the XDG base directories games usually keep saves
+// and settings in, relative to the sandboxed home folder
+var sandboxXDGDirs = []struct {
+	name string
+	path string
+}{
+	{"XDG_CONFIG_HOME", ".config"},
+	{"XDG_DATA_HOME", ".local/share"},
+	{"XDG_CACHE_HOME", ".cache"},
+}
+
+func (br *bwrapRunner) Prepare() error {
+	// bwrap can't bind folders that don't exist
+	dirs := []string{br.params.TempDir, br.gameHome}
+	for _, xdg := range sandboxXDGDirs {
+		dirs = append(dirs, filepath.Join(br.gameHome, filepath.FromSlash(xdg.path)))
+	}
+	for _, dir := range dirs {
+		err := os.MkdirAll(dir, 0o755)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+func (br *bwrapRunner) Run() error {
+	params := br.params
+	consumer := params.Consumer
+
+	bwrapPath, err := exec.LookPath("bwrap")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	args := bwrapArgs(params, br.gameHome, br.noNetwork)
+	consumer.Opf("Running (%s) through bubblewrap", params.FullTargetPath)
+	consumer.Debugf("bwrap arguments: %s", strings.Join(args, " ::: "))
+
+	cmd := exec.Command(bwrapPath, args...)
+	cmd.Dir = params.Dir
+	cmd.Env = params.Env
+	cmd.Stdout = params.Stdout
+	cmd.Stderr = params.Stderr
+
+	pg, err := runner.NewProcessGroup(consumer, cmd, params.Ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = pg.AfterStart()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = pg.Wait()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// bwrapArgs returns bwrap's arguments, including the command to run.
+// Mounts are applied in order, so later ones punch holes in earlier ones.
+func bwrapArgs(params runner.RunnerParams, gameHome string, noNetwork bool) []string {
+	env := envMap(params.Env)
+	home := env["HOME"]
+	installFolder := params.InstallFolder
+
+	args := []string{
+		"--die-with-parent",
+		"--new-session",
+		"--unshare-pid",
+		"--ro-bind", "/", "/",
+		"--dev-bind", "/dev", "/dev",
+		"--proc", "/proc",
+		"--tmpfs", "/tmp",
+	}
+	if noNetwork {
+		args = append(args, "--unshare-net")
+	}
+
+	// X11 clients talk to the server through sockets in /tmp
+	if exists("/tmp/.X11-unix") {
+		args = append(args, "--ro-bind", "/tmp/.X11-unix", "/tmp/.X11-unix")
+	}
+
+	// HOME doesn't change, but the real home folder is hidden, along with
+	// the butler database, credentials and other games' sandboxed homes.
+	args = append(args, "--bind", gameHome, home)
+	for _, xdg := range sandboxXDGDirs {
+		args = append(args, "--setenv", xdg.name, filepath.Join(home, filepath.FromSlash(xdg.path)))
+	}
+
+	// the X11 cookie is usually in the home folder, which is now hidden
+	xauthority := env["XAUTHORITY"]
+	if xauthority == "" {
+		xauthority = filepath.Join(home, ".Xauthority")
+	}
+	if exists(xauthority) {
+		args = append(args, "--ro-bind", xauthority, xauthority)
+	}
+
+	args = append(args,
+		"--ro-bind", installFolder, installFolder,
+		// receipts, logs, etc. are none of the game's business
+		"--tmpfs", filepath.Join(installFolder, ".itch"),
+		"--bind", params.TempDir, params.TempDir,
+	)
+	if params.Dir != "" {
+		args = append(args, "--chdir", params.Dir)
+	}
+
+	args = append(args, params.FullTargetPath)
+	args = append(args, params.Args...)
+	return args
+}
+
+func envMap(envBlock []string) map[string]string {
+	env := make(map[string]string)
+	for _, kv := range envBlock {
+		tokens := strings.SplitN(kv, "=", 2)
+		if len(tokens) == 2 {
+			env[tokens[0]] = tokens[1]
+		}
+	}
+	return env
+}
+
+func exists(path string) bool {
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
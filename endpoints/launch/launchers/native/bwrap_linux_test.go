@@ -0,0 +1,172 @@
+// +build linux
+
+package native
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/headway/state"
+	"github.com/itchio/smaug/runner"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BwrapArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	params := runner.RunnerParams{
+		FullTargetPath: "/games/advent/bin/advent",
+		Args:           []string{"-windowed"},
+		Dir:            "/games/advent/bin",
+		Env:            []string{"HOME=/home/user", "XDG_CONFIG_HOME=/home/user/settings", "PATH=/usr/bin"},
+		InstallFolder:  "/games/advent",
+		TempDir:        "/games/advent/.itch/temp",
+	}
+	env := envMap(params.Env)
+	gameHome := sandboxHome(env, 42)
+	assert.EqualValues("/home/user/.local/share/itch/sandbox-homes/game-42", gameHome)
+
+	args := bwrapArgs(params, gameHome, false)
+	joined := strings.Join(args, " ")
+
+	assert.Contains(joined, "--ro-bind / /")
+	assert.Contains(joined, "--tmpfs /tmp")
+	assert.Contains(joined, "--bind /home/user/.local/share/itch/sandbox-homes/game-42 /home/user", "real home is hidden")
+	assert.Contains(joined, "--setenv XDG_CONFIG_HOME /home/user/.config", "XDG folders are inside the sandboxed home")
+	assert.Contains(joined, "--setenv XDG_DATA_HOME /home/user/.local/share")
+	assert.Contains(joined, "--setenv XDG_CACHE_HOME /home/user/.cache")
+	assert.NotContains(joined, "/home/user/settings", "the real XDG folders are hidden")
+	assert.Contains(joined, "--ro-bind /games/advent /games/advent")
+	assert.NotContains(joined, "--bind /games/advent /games/advent")
+	assert.Contains(joined, "--tmpfs /games/advent/.itch")
+	assert.Contains(joined, "--bind /games/advent/.itch/temp /games/advent/.itch/temp")
+	assert.Contains(joined, "--chdir /games/advent/bin")
+	assert.NotContains(joined, "--setenv HOME")
+	assert.NotContains(joined, "--unshare-net")
+
+	// later mounts must come after the ones they punch holes in, or they'd be covered
+	assert.True(strings.Index(joined, "--ro-bind /games/advent ") < strings.Index(joined, "--tmpfs /games/advent/.itch"))
+	assert.True(strings.Index(joined, "--tmpfs /games/advent/.itch") < strings.Index(joined, "--bind /games/advent/.itch/temp"))
+
+	assert.EqualValues([]string{"/games/advent/bin/advent", "-windowed"}, args[len(args)-2:])
+
+	args = bwrapArgs(params, gameHome, true)
+	assert.Contains(args, "--unshare-net")
+}
+
+func Test_PickSandboxBackend(t *testing.T) {
+	assert := assert.New(t)
+
+	found := func(file string) (string, error) {
+		return "/usr/bin/" + file, nil
+	}
+	notFound := func(file string) (string, error) {
+		return "", errors.Errorf("%s not found", file)
+	}
+
+	for _, lookPath := range []func(string) (string, error){found, notFound} {
+		backend, err := pickSandboxBackend(butlerd.SandboxBackendAuto, lookPath)
+		assert.NoError(err)
+		assert.EqualValues(butlerd.SandboxBackendFirejail, backend, "firejail is the default, even if bubblewrap is installed")
+
+		backend, err = pickSandboxBackend(butlerd.SandboxBackendFirejail, lookPath)
+		assert.NoError(err)
+		assert.EqualValues(butlerd.SandboxBackendFirejail, backend)
+	}
+
+	backend, err := pickSandboxBackend(butlerd.SandboxBackendBwrap, found)
+	assert.NoError(err)
+	assert.EqualValues(butlerd.SandboxBackendBwrap, backend)
+
+	_, err = pickSandboxBackend(butlerd.SandboxBackendBwrap, notFound)
+	assert.Error(err, "bubblewrap must be installed when asked for")
+}
+
+func Test_BwrapSaves(t *testing.T) {
+	if _, err := exec.LookPath("bwrap"); err != nil {
+		t.Skipf("bubblewrap not installed: %v", err)
+	}
+	assert := assert.New(t)
+
+	root, err := ioutil.TempDir("", "bwrap-saves")
+	must(t, err)
+	defer os.RemoveAll(root)
+
+	home := filepath.Join(root, "home")
+	installFolder := filepath.Join(root, "games", "advent")
+	must(t, os.MkdirAll(installFolder, 0o755))
+
+	// the real home folder, with the butler database in it
+	dbPath := filepath.Join(home, ".config", "itch", "db", "butler.db")
+	must(t, os.MkdirAll(filepath.Dir(dbPath), 0o755))
+	must(t, ioutil.WriteFile(dbPath, []byte("credentials"), 0o644))
+
+	gamePath := filepath.Join(installFolder, "advent.sh")
+	must(t, ioutil.WriteFile(gamePath, []byte(`#!/bin/sh
+set -e
+if cat "$HOME/.config/itch/db/butler.db" 2>/dev/null; then echo "read database"; fi
+mkdir -p "$XDG_DATA_HOME/advent"
+echo "level 4" > "$XDG_DATA_HOME/advent/new.sav"
+mkdir -p "$HOME/.advent"
+echo "fullscreen" > "$HOME/.advent/settings.ini"
+if echo "high scores" 2>/dev/null > "$(dirname "$0")/scores.txt"; then echo "wrote install folder"; fi
+echo "temp" > "$(dirname "$0")/.itch/temp/scratch"
+`), 0o755))
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	params := runner.RunnerParams{
+		Consumer:       &state.Consumer{},
+		Ctx:            context.Background(),
+		Sandbox:        true,
+		FullTargetPath: gamePath,
+		Name:           gamePath,
+		Dir:            installFolder,
+		Env:            []string{"HOME=" + home, "XDG_DATA_HOME=" + filepath.Join(home, "data"), "PATH=" + os.Getenv("PATH")},
+		Stdout:         &stdout,
+		Stderr:         &stderr,
+		TempDir:        filepath.Join(installFolder, ".itch", "temp"),
+		InstallFolder:  installFolder,
+	}
+
+	run, err := newBwrapRunner(params, 42, false)
+	must(t, err)
+	must(t, run.Prepare())
+	err = run.Run()
+	if err != nil {
+		t.Fatalf("%+v\nstderr: %s", err, stderr.String())
+	}
+
+	assert.EqualValues("", stdout.String(), "the real home folder is hidden and the install folder is read-only")
+
+	gameHome := sandboxHome(envMap(params.Env), 42)
+	assert.EqualValues(filepath.Join(home, "data", "itch", "sandbox-homes", "game-42"), gameHome)
+
+	contents, err := ioutil.ReadFile(filepath.Join(gameHome, ".local", "share", "advent", "new.sav"))
+	must(t, err)
+	assert.EqualValues("level 4\n", string(contents), "XDG saves are kept in the sandboxed home")
+
+	contents, err = ioutil.ReadFile(filepath.Join(gameHome, ".advent", "settings.ini"))
+	must(t, err)
+	assert.EqualValues("fullscreen\n", string(contents), "other files in the home folder persist outside the install folder")
+
+	_, err = os.Stat(filepath.Join(installFolder, "scores.txt"))
+	assert.True(os.IsNotExist(err), "games can't write next to their executable")
+
+	_, err = os.Stat(filepath.Join(home, "data", "advent"))
+	assert.True(os.IsNotExist(err), "the real XDG folders are untouched")
+}
+
+func must(t *testing.T, err error) {
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+}
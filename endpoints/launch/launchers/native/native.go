@@ -69,6 +69,13 @@ func (l *Launcher) Do(params launch.LauncherParams) error {
 		consumer.Warnf("Could not determine PE info: %s", err.Error())
 	}
 
+	if params.Sandbox {
+		params.SandboxBackend, err = resolveSandboxBackend(params)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = handlePrereqs(params)
 	if err != nil {
 		if be, ok := butlerd.AsButlerdError(err); ok {
@@ -103,6 +110,11 @@ func (l *Launcher) Do(params launch.LauncherParams) error {
 	}
 
 	envMap := make(map[string]string)
+	if params.Host.Wrapper != nil {
+		for k, v := range params.Host.Wrapper.Env {
+			envMap[k] = v
+		}
+	}
 	for k, v := range params.Env {
 		envMap[k] = v
 	}
@@ -215,7 +227,7 @@ func (l *Launcher) Do(params launch.LauncherParams) error {
 		FujiParams:     l.FujiParams(params),
 	}
 
-	run, err := runner.GetRunner(runParams)
+	run, err := getRunner(params, runParams)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -231,6 +243,8 @@ func (l *Launcher) Do(params launch.LauncherParams) error {
 
 		messages.LaunchRunning.Notify(params.RequestContext, butlerd.LaunchRunningNotification{})
 		exitCode, err := interpretRunError(run.Run())
+		stdout.Close()
+		stderr.Close()
 		messages.LaunchExited.Notify(params.RequestContext, butlerd.LaunchExitedNotification{})
 		if err != nil {
 			return err
@@ -238,17 +252,18 @@ func (l *Launcher) Do(params launch.LauncherParams) error {
 
 		runDuration := time.Since(startTime)
 
-		if exitCode != 0 {
-			var signedExitCode = int64(exitCode)
-			if runtime.GOOS == "windows" {
-				// Windows uses 32-bit unsigned integers as exit codes, although the
-				// command interpreter treats them as signed. If a process fails
-				// initialization, a Windows system error code may be returned.
-				signedExitCode = int64(int32(signedExitCode))
-
-				// The line above turns `4294967295` into -1
-			}
+		var signedExitCode = int64(exitCode)
+		if runtime.GOOS == "windows" {
+			// Windows uses 32-bit unsigned integers as exit codes, although the
+			// command interpreter treats them as signed. If a process fails
+			// initialization, a Windows system error code may be returned.
+			signedExitCode = int64(int32(signedExitCode))
 
+			// The line above turns `4294967295` into -1
+		}
+		params.SessionExited(signedExitCode, stdout.Lines(), stderr.Lines())
+
+		if exitCode != 0 {
 			exeName := filepath.Base(params.FullTargetPath)
 			msg := fmt.Sprintf("Exit code 0x%x (%d) for (%s)", uint32(exitCode), signedExitCode, exeName)
 			consumer.Warnf(msg)
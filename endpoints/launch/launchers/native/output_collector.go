@@ -3,11 +3,14 @@ package native
 import (
 	"bufio"
 	"io"
+	"sync"
 )
 
 type outputCollector struct {
 	lines  []string
-	writer io.Writer
+	writer *io.PipeWriter
+	lock   sync.Mutex
+	done   chan struct{}
 }
 
 var _ io.Writer = (*outputCollector)(nil)
@@ -17,27 +20,41 @@ func newOutputCollector(maxLines int) *outputCollector {
 
 	oc := &outputCollector{
 		writer: pipeW,
+		done:   make(chan struct{}),
 	}
 
 	go func() {
+		defer close(oc.done)
 		s := bufio.NewScanner(pipeR)
 		for s.Scan() {
 			line := s.Text()
+			oc.lock.Lock()
 			oc.lines = append(oc.lines, line)
 
 			if len(oc.lines) > maxLines {
 				oc.lines = oc.lines[1:]
 			}
+			oc.lock.Unlock()
 		}
 	}()
 
 	return oc
 }
 
+// Lines returns a copy of the last lines written so far
 func (oc *outputCollector) Lines() []string {
-	return oc.lines
+	oc.lock.Lock()
+	defer oc.lock.Unlock()
+	return append([]string(nil), oc.lines...)
 }
 
 func (oc *outputCollector) Write(p []byte) (int, error) {
 	return oc.writer.Write(p)
 }
+
+// Close waits for lines already written to be collected.
+// It must only be called once the process has exited.
+func (oc *outputCollector) Close() {
+	oc.writer.Close()
+	<-oc.done
+}
@@ -57,7 +57,7 @@ func handlePrereqs(params launch.LauncherParams) error {
 	// append built-in params if we need some
 	{
 		runtime := params.Host.Runtime
-		if runtime.Platform == ox.PlatformLinux && params.Sandbox {
+		if runtime.Platform == ox.PlatformLinux && params.Sandbox && params.SandboxBackend == butlerd.SandboxBackendFirejail {
 			firejailName := fmt.Sprintf("firejail-%s", runtime.Arch())
 			wanted = append(wanted, firejailName)
 		}
@@ -0,0 +1,49 @@
+// +build linux
+
+package native
+
+import (
+	"os/exec"
+
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/endpoints/launch"
+	"github.com/itchio/smaug/runner"
+	"github.com/pkg/errors"
+)
+
+// resolveSandboxBackend returns the sandbox backend to use, firejail
+// unless bubblewrap was asked for.
+func resolveSandboxBackend(params launch.LauncherParams) (butlerd.SandboxBackend, error) {
+	consumer := params.RequestContext.Consumer
+
+	backend, err := pickSandboxBackend(params.SandboxBackend, exec.LookPath)
+	if err != nil {
+		return "", err
+	}
+	consumer.Infof("Using sandbox backend (%s)", backend)
+
+	if params.SandboxNoNetwork && backend != butlerd.SandboxBackendBwrap {
+		consumer.Warnf("Sandbox backend (%s) can't deny network access, game will be online", backend)
+	}
+	return backend, nil
+}
+
+func pickSandboxBackend(requested butlerd.SandboxBackend, lookPath func(file string) (string, error)) (butlerd.SandboxBackend, error) {
+	switch requested {
+	case butlerd.SandboxBackendBwrap:
+		_, err := lookPath("bwrap")
+		if err != nil {
+			return "", errors.Wrap(err, "bubblewrap sandbox requested")
+		}
+		return butlerd.SandboxBackendBwrap, nil
+	default:
+		return butlerd.SandboxBackendFirejail, nil
+	}
+}
+
+func getRunner(params launch.LauncherParams, runParams runner.RunnerParams) (runner.Runner, error) {
+	if runParams.Sandbox && params.SandboxBackend == butlerd.SandboxBackendBwrap {
+		return newBwrapRunner(runParams, params.GameID, params.SandboxNoNetwork)
+	}
+	return runner.GetRunner(runParams)
+}
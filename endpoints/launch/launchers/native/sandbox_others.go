@@ -0,0 +1,19 @@
+// +build !linux
+
+package native
+
+import (
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/endpoints/launch"
+	"github.com/itchio/smaug/runner"
+)
+
+// resolveSandboxBackend only matters on Linux, other platforms
+// have a single sandbox.
+func resolveSandboxBackend(params launch.LauncherParams) (butlerd.SandboxBackend, error) {
+	return params.SandboxBackend, nil
+}
+
+func getRunner(params launch.LauncherParams, runParams runner.RunnerParams) (runner.Runner, error) {
+	return runner.GetRunner(runParams)
+}
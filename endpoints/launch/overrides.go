@@ -0,0 +1,100 @@
+package launch
+
+import (
+	"path/filepath"
+
+	"crawshaw.io/sqlite"
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/database/models"
+	"github.com/itchio/butler/manager"
+	"github.com/itchio/hades"
+	"github.com/itchio/headway/state"
+	"xorm.io/builder"
+)
+
+// preferredTargets keeps the targets whose host matches the cave's
+// launch overrides, or all of them if none do.
+func preferredTargets(consumer *state.Consumer, targets []*butlerd.LaunchTarget, overrides *butlerd.LaunchOverrides) []*butlerd.LaunchTarget {
+	if overrides == nil || (overrides.PreferredRuntime == nil && overrides.PreferredWrapper == "") {
+		return targets
+	}
+
+	var res []*butlerd.LaunchTarget
+	for _, t := range targets {
+		if isPreferredHost(t.Host, overrides) {
+			res = append(res, t)
+		}
+	}
+
+	if len(res) == 0 {
+		consumer.Warnf("No launch targets for preferred host, considering all hosts")
+		return targets
+	}
+	consumer.Infof("Keeping (%d/%d) targets for preferred host", len(res), len(targets))
+	return res
+}
+
+func isPreferredHost(host manager.Host, overrides *butlerd.LaunchOverrides) bool {
+	if overrides.PreferredRuntime != nil && *overrides.PreferredRuntime != host.Runtime {
+		return false
+	}
+
+	switch overrides.PreferredWrapper {
+	case "":
+		return true
+	case "native":
+		return host.Wrapper == nil
+	default:
+		if host.Wrapper == nil {
+			return false
+		}
+		wrapper := host.Wrapper.WrapperBinary
+		return wrapper == overrides.PreferredWrapper || filepath.Base(wrapper) == overrides.PreferredWrapper
+	}
+}
+
+// rememberedTarget returns the target saved in the cave's launch
+// overrides, or nil if there's none, or it's not the same target anymore.
+func rememberedTarget(consumer *state.Consumer, targets []*butlerd.LaunchTarget, overrides *butlerd.LaunchOverrides) *butlerd.LaunchTarget {
+	if overrides == nil || overrides.ActionIndex == nil {
+		return nil
+	}
+
+	index := *overrides.ActionIndex
+	if index < 0 || index >= int64(len(targets)) {
+		consumer.Warnf("Remembered target (%d) is out of range (%d targets), ignoring", index, len(targets))
+		return nil
+	}
+
+	target := targets[index]
+	if target.Action.Name != overrides.ActionName || target.Host.String() != overrides.ActionHost {
+		consumer.Warnf("Remembered target (%d) was (%s) for host (%s), is now (%s) for host (%s), ignoring",
+			index, overrides.ActionName, overrides.ActionHost, target.Action.Name, target.Host)
+		return nil
+	}
+	return target
+}
+
+// rememberTarget saves the target picked by the user in the cave's
+// launch overrides, so they're not asked again.
+func rememberTarget(rc *butlerd.RequestContext, cave *models.Cave, overrides *butlerd.LaunchOverrides, index int64, target *butlerd.LaunchTarget) {
+	overrides.ActionIndex = &index
+	overrides.ActionName = target.Action.Name
+	overrides.ActionHost = target.Host.String()
+
+	var contents models.JSON
+	err := butlerd.MarshalLaunchOverrides(overrides, &contents)
+	if err != nil {
+		rc.Consumer.Warnf("Could not remember picked target: %v", err)
+		return
+	}
+
+	cave.LaunchOverrides = contents
+	rc.WithConn(func(conn *sqlite.Conn) {
+		models.MustUpdate(conn, &models.Cave{},
+			hades.Where(builder.Eq{"id": cave.ID}),
+			builder.Eq{"launch_overrides": contents},
+		)
+	})
+	rc.Consumer.Infof("Remembering picked target (%d) (%s) for next launches", index, overrides.ActionName)
+}
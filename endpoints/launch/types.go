@@ -44,6 +44,10 @@ type LauncherParams struct {
 	// If true, enable sandbox
 	Sandbox bool
 
+	// Only used on Linux, if Sandbox is true
+	SandboxBackend   butlerd.SandboxBackend
+	SandboxNoNetwork bool
+
 	// Additional command-line arguments
 	Args []string
 
@@ -53,10 +57,15 @@ type LauncherParams struct {
 	PrereqsDir    string
 	ForcePrereqs  bool
 	Access        *operate.GameAccess
+	GameID        int64
 	InstallFolder string
 	Host          manager.Host
 
 	SessionStarted func()
+
+	// Called by launchers that run a process, once it has exited,
+	// with the last lines it wrote to stdout and stderr
+	SessionExited func(exitCode int64, stdout []string, stderr []string)
 }
 
 // cf. https://github.com/itchio/itch/issues/1751
@@ -1,12 +1,15 @@
 package meta
 
 import (
+	"encoding/json"
 	"log"
 	"os"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/generous/spec"
 	"github.com/itchio/butler/butlerd/messages"
 	"github.com/pkg/errors"
 )
@@ -14,6 +17,23 @@ import (
 var establishedAt *time.Time
 var establishedLock sync.Mutex
 
+var parsedSpec *spec.Spec
+var parsedSpecOnce sync.Once
+var parsedSpecErr error
+
+func loadSpec() (*spec.Spec, error) {
+	parsedSpecOnce.Do(func() {
+		var s spec.Spec
+		parsedSpecErr = json.Unmarshal([]byte(spec.JSON), &s)
+		if parsedSpecErr != nil {
+			parsedSpecErr = errors.WithMessage(parsedSpecErr, "parsing embedded spec")
+			return
+		}
+		parsedSpec = &s
+	})
+	return parsedSpec, parsedSpecErr
+}
+
 func Register(router *butlerd.Router) {
 	messages.MetaAuthenticate.Register(router, func(rc *butlerd.RequestContext, params butlerd.MetaAuthenticateParams) (*butlerd.MetaAuthenticateResult, error) {
 		return nil, errors.Errorf("Meta.Authenticate not needed (and not valid) for your current transport")
@@ -43,6 +63,23 @@ func Register(router *butlerd.Router) {
 		}
 		return &butlerd.MetaFlowResult{}, nil
 	})
+	messages.MetaIntrospect.Register(router, func(rc *butlerd.RequestContext, params butlerd.MetaIntrospectParams) (*butlerd.MetaIntrospectResult, error) {
+		s, err := loadSpec()
+		if err != nil {
+			return nil, err
+		}
+
+		var methods []string
+		for method := range router.Handlers {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+
+		return &butlerd.MetaIntrospectResult{
+			Spec:    s,
+			Methods: methods,
+		}, nil
+	})
 	messages.MetaShutdown.Register(router, func(rc *butlerd.RequestContext, params butlerd.MetaShutdownParams) (*butlerd.MetaShutdownResult, error) {
 		rc.Shutdown()
 		return &butlerd.MetaShutdownResult{}, nil
@@ -0,0 +1,13 @@
+package tasks
+
+import (
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/messages"
+)
+
+func Register(router *butlerd.Router) {
+	messages.TasksList.Register(router, TasksList)
+	messages.TasksCancel.Register(router, TasksCancel)
+
+	router.RegisterBackgroundTask(fetchUserGameSessionsKind, doFetchUserGameSessions)
+}
@@ -0,0 +1,19 @@
+package tasks
+
+import (
+	"github.com/itchio/butler/butlerd"
+)
+
+func TasksCancel(rc *butlerd.RequestContext, params butlerd.TasksCancelParams) (*butlerd.TasksCancelResult, error) {
+	didCancel := rc.CancelBackgroundTask(butlerd.BackgroundTaskID(params.TaskID))
+	if didCancel {
+		rc.Consumer.Statf("Cancelled background task %s", params.TaskID)
+	} else {
+		rc.Consumer.Warnf("No such background task: %s", params.TaskID)
+	}
+
+	res := &butlerd.TasksCancelResult{
+		DidCancel: didCancel,
+	}
+	return res, nil
+}
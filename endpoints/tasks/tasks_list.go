@@ -0,0 +1,37 @@
+package tasks
+
+import (
+	"crawshaw.io/sqlite"
+	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/database/models"
+)
+
+func TasksList(rc *butlerd.RequestContext, params butlerd.TasksListParams) (*butlerd.TasksListResult, error) {
+	var tasks []*models.BackgroundTask
+	rc.WithConn(func(conn *sqlite.Conn) {
+		tasks = models.AllBackgroundTasks(conn)
+	})
+
+	var ftasks []*butlerd.Task
+	for _, t := range tasks {
+		ftasks = append(ftasks, formatTask(t))
+	}
+
+	res := &butlerd.TasksListResult{
+		Tasks: ftasks,
+	}
+	return res, nil
+}
+
+func formatTask(task *models.BackgroundTask) *butlerd.Task {
+	return &butlerd.Task{
+		ID:            task.ID,
+		Kind:          task.Kind,
+		Desc:          task.Desc,
+		Attempts:      task.Attempts,
+		LastError:     task.LastError,
+		QueuedAt:      task.QueuedAt,
+		NextAttemptAt: task.NextAttemptAt,
+		GaveUpAt:      task.GaveUpAt,
+	}
+}
@@ -1,11 +1,11 @@
 package utilities
 
 import (
-	"github.com/efarrer/iothrottler"
 	"github.com/itchio/butler/buildinfo"
 	"github.com/itchio/butler/butlerd"
+	"github.com/itchio/butler/butlerd/bandwidth"
 	"github.com/itchio/butler/butlerd/messages"
-	"github.com/itchio/httpkit/timeout"
+	"github.com/itchio/butler/butlerd/netstatus"
 )
 
 func Register(router *butlerd.Router) {
@@ -18,7 +18,7 @@ func Register(router *butlerd.Router) {
 
 	messages.NetworkSetSimulateOffline.Register(router, func(rc *butlerd.RequestContext, params butlerd.NetworkSetSimulateOfflineParams) (*butlerd.NetworkSetSimulateOfflineResult, error) {
 		rc.Consumer.Infof("Setting offline mode to: %v", params.Enabled)
-		timeout.SetSimulateOffline(params.Enabled)
+		netstatus.SetSimulateOffline(params.Enabled)
 
 		if params.Enabled {
 			// with http/2, we need to do this, otherwise it'll re-use existing connections
@@ -32,11 +32,25 @@ func Register(router *butlerd.Router) {
 
 	messages.NetworkSetBandwidthThrottle.Register(router, func(rc *butlerd.RequestContext, params butlerd.NetworkSetBandwidthThrottleParams) (*butlerd.NetworkSetBandwidthThrottleResult, error) {
 		if params.Enabled {
-			timeout.ThrottlerPool.SetBandwidth(iothrottler.Bandwidth(params.Rate) * iothrottler.Kbps)
+			bandwidth.Set(bandwidth.SourceUser, params.Rate)
 		} else {
-			timeout.ThrottlerPool.SetBandwidth(iothrottler.Unlimited)
+			bandwidth.Set(bandwidth.SourceUser, 0)
 		}
 		res := &butlerd.NetworkSetBandwidthThrottleResult{}
 		return res, nil
 	})
+
+	messages.NetworkGetStatus.Register(router, func(rc *butlerd.RequestContext, params butlerd.NetworkGetStatusParams) (*butlerd.NetworkGetStatusResult, error) {
+		snapshot := netstatus.Get()
+		res := &butlerd.NetworkGetStatusResult{
+			Status:          butlerd.NetworkStatus(snapshot.Status),
+			ProbeURL:        snapshot.ProbeURL,
+			SimulateOffline: snapshot.SimulateOffline,
+			FailedProbes:    snapshot.FailedProbes,
+			LastProbeAt:     snapshot.LastProbeAt,
+			NextProbeAt:     snapshot.NextProbeAt,
+			LastError:       snapshot.LastError,
+		}
+		return res, nil
+	})
 }
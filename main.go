@@ -12,9 +12,9 @@ import (
 	"runtime/pprof"
 	"time"
 
-	"github.com/efarrer/iothrottler"
-
 	"github.com/itchio/butler/buildinfo"
+	"github.com/itchio/butler/butlerd/bandwidth"
+	"github.com/itchio/butler/butlerd/netstatus"
 	"github.com/itchio/butler/cmd/elevate"
 	"github.com/itchio/butler/comm"
 	"github.com/itchio/butler/filtering"
@@ -26,7 +26,6 @@ import (
 
 	"github.com/itchio/httpkit/eos"
 	"github.com/itchio/httpkit/eos/option"
-	"github.com/itchio/httpkit/timeout"
 
 	shellquote "github.com/kballard/go-shellquote"
 
@@ -219,11 +218,11 @@ func doMain(args []string) {
 		throttle := *appArgs.throttle
 		bwKiloBytes := throttle / 8 * 1024
 		comm.Logf("Throttling to %s/s bandwidth", united.FormatBytes(bwKiloBytes))
-		timeout.ThrottlerPool.SetBandwidth(iothrottler.Bandwidth(throttle) * iothrottler.Kbps)
+		bandwidth.Set(bandwidth.SourceUser, throttle)
 	}
 
 	if *appArgs.simulateOffline {
-		timeout.SetSimulateOffline(true)
+		netstatus.SetSimulateOffline(true)
 	}
 
 	fullCmd := kingpin.MustParse(cmd, err)
@@ -246,6 +245,7 @@ func doMain(args []string) {
 			UserAgent:  ctx.UserAgent(),
 		})
 		option.SetDefaultConsumer(comm.NewStateConsumer())
+		bandwidth.Routed(ctx.HTTPClient)
 		option.SetDefaultHTTPClient(ctx.HTTPClient)
 	}
 
@@ -18,7 +18,9 @@ type HostEnumerator interface {
 	Enumerate(consumer *state.Consumer) (Hosts, error)
 }
 
-type defaultHostEnumerator struct{}
+type defaultHostEnumerator struct {
+	custom Hosts
+}
 
 var _ HostEnumerator = (*defaultHostEnumerator)(nil)
 
@@ -26,6 +28,16 @@ func DefaultHostEnumerator() HostEnumerator {
 	return &defaultHostEnumerator{}
 }
 
+// CustomHostEnumerator returns an enumerator like DefaultHostEnumerator,
+// with user-declared wrapper hosts (Proton, box64, etc.) preferred over
+// the ones found automatically, like wine. Hosts whose wrapper can't
+// be found are skipped.
+func CustomHostEnumerator(custom Hosts) HostEnumerator {
+	return &defaultHostEnumerator{
+		custom: custom,
+	}
+}
+
 func (h Host) Validate() error {
 	if h.Runtime.Platform == "" {
 		return errors.Errorf("invalid host (empty platform)")
@@ -47,6 +59,21 @@ func (dre *defaultHostEnumerator) Enumerate(consumer *state.Consumer) (Hosts, er
 		native,
 	}
 
+	for _, h := range dre.custom {
+		if h.Wrapper == nil {
+			consumer.Warnf("Ignoring custom host %v: no wrapper", h)
+			continue
+		}
+
+		wrapperPath, err := exec.LookPath(h.Wrapper.WrapperBinary)
+		if err != nil {
+			consumer.Warnf("Ignoring custom host %v: %v", h, err)
+			continue
+		}
+		consumer.Debugf("Found custom host wrapper at: (%s)", wrapperPath)
+		rts = append(rts, h)
+	}
+
 	if native.Runtime.Platform != ox.PlatformWindows {
 		consumer.Debugf("Looking for wine...")
 
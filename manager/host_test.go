@@ -0,0 +1,62 @@
+package manager_test
+
+import (
+	"testing"
+
+	"github.com/itchio/butler/manager"
+	itchio "github.com/itchio/go-itchio"
+	"github.com/itchio/ox"
+	"github.com/itchio/wharf/wtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CustomHostEnumerator(t *testing.T) {
+	consumer := makeTestConsumer(t)
+
+	windows64 := ox.Runtime{Platform: ox.PlatformWindows, Is64: true}
+	hosts, err := manager.CustomHostEnumerator(manager.Hosts{
+		{
+			Runtime: windows64,
+			Wrapper: &manager.Wrapper{
+				WrapperBinary: "go",
+				BeforeTarget:  []string{"run"},
+			},
+		},
+		{
+			Runtime: windows64,
+			Wrapper: &manager.Wrapper{
+				WrapperBinary: "/does/not/exist/proton",
+			},
+		},
+	}).Enumerate(consumer)
+	wtest.Must(t, err)
+
+	assert.True(t, len(hosts) >= 2)
+	assert.EqualValues(t, manager.NativeHost(), hosts[0])
+	assert.EqualValues(t, windows64, hosts[1].Runtime)
+	assert.EqualValues(t, "go", hosts[1].Wrapper.WrapperBinary)
+	for _, h := range hosts {
+		if h.Wrapper != nil {
+			assert.NotEqual(t, "/does/not/exist/proton", h.Wrapper.WrapperBinary, "hosts with a missing wrapper are skipped")
+		}
+	}
+
+	if hosts[0].Runtime.Platform == ox.PlatformWindows {
+		return
+	}
+
+	game := &itchio.Game{
+		Classification: itchio.GameClassificationGame,
+	}
+	uploads := []*itchio.Upload{
+		{
+			Platforms: itchio.Platforms{Windows: "all"},
+			Filename:  "game-setup.exe",
+			Type:      "default",
+		},
+	}
+
+	res, err := manager.NarrowDownUploads(consumer, game, uploads, manager.CustomHostEnumerator(manager.Hosts{hosts[1]}))
+	wtest.Must(t, err)
+	assert.Len(t, res.Uploads, 1, "windows uploads are compatible with a custom windows host")
+}